@@ -0,0 +1,46 @@
+// Command fakecod serves a fake CoD API from fixtures for offline development, or with
+// -record proxies the real API and saves its responses as new fixtures.
+//
+// Point the server at it with COD_API_BASE_URL=http://localhost:8090 and any non-empty
+// COD_SSO_TOKEN.
+package main
+
+import (
+	"flag"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient/fake"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "listen address")
+	fixtures := flag.String("fixtures", "", "fixture directory (default: bundled fixtures)")
+	record := flag.String("record", "", "upstream CoD API base URL to proxy and record from")
+	flag.Parse()
+
+	var h http.Handler
+	switch {
+	case *record != "":
+		dir := *fixtures
+		if dir == "" {
+			dir = "fixtures"
+		}
+		h = fake.NewRecorder(*record, dir)
+		slog.Info("recording cod api responses", "upstream", *record, "dir", dir)
+	default:
+		var fsys fs.FS = fake.DefaultFixtures()
+		if *fixtures != "" {
+			fsys = os.DirFS(*fixtures)
+		}
+		h = fake.New(fsys)
+	}
+
+	slog.Info("fake cod api listening", "addr", *addr)
+	if err := http.ListenAndServe(*addr, h); err != nil {
+		slog.Error("fake cod api stopped", "error", err)
+		os.Exit(1)
+	}
+}
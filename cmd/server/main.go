@@ -9,17 +9,20 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/grovecj/warzone-stats-tracker/internal/cache"
 	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient/demo"
 	"github.com/grovecj/warzone-stats-tracker/internal/config"
 	"github.com/grovecj/warzone-stats-tracker/internal/database"
 	"github.com/grovecj/warzone-stats-tracker/internal/handler"
 	"github.com/grovecj/warzone-stats-tracker/internal/repository"
 	"github.com/grovecj/warzone-stats-tracker/internal/router"
 	"github.com/grovecj/warzone-stats-tracker/internal/service"
+	"github.com/grovecj/warzone-stats-tracker/internal/tracker"
 	"github.com/grovecj/warzone-stats-tracker/web"
 )
 
@@ -51,9 +54,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	// CoD API client with caching
-	codAPI := codclient.New(cfg.CodAPIBaseURL, cfg.CodSSOToken)
-	cachedAPI := cache.New(codAPI, cache.DefaultConfig())
+	// CoD API client with caching; demo mode swaps in synthetic data and needs no token
+	var codAPI codclient.CodClient
+	if cfg.DemoMode {
+		codAPI = demo.New()
+		slog.Warn("demo mode enabled, serving synthetic player data")
+	} else {
+		codAPI = codclient.New(cfg.CodAPIBaseURL, cfg.CodSSOToken, codclient.RateLimit{
+			PerMinute: cfg.CodRequestsPerMin,
+			Burst:     cfg.CodRequestBurst,
+		}, cfg.CodTokenCooldown)
+		for name, token := range cfg.SSOTokenPool() {
+			codAPI.AddToken(name, token)
+		}
+	}
+	// A nil store gives the bounded in-process memory store
+	var cacheStore cache.Store
+	if cfg.CacheBackend == "postgres" {
+		cacheStore = cache.NewPostgresStore(pool)
+	}
+	slog.Info("cache backend selected", "backend", cfg.CacheBackend)
+	cachedAPI := cache.New(codAPI, cacheStore, cache.Config{
+		StatsSoftTTL: cfg.CacheStatsSoftTTL,
+		StatsHardTTL: cfg.CacheStatsHardTTL,
+		MatchSoftTTL: cfg.CacheMatchSoftTTL,
+		MatchHardTTL: cfg.CacheMatchHardTTL,
+		MaxEntries:   cfg.CacheMaxEntries,
+		MaxBytes:     int64(cfg.CacheMaxBytes),
+		NotFoundTTL:  cfg.CacheNotFoundTTL,
+		PrivateTTL:   cfg.CachePrivateTTL,
+	})
 
 	// Static files — use embedded FS in production, nil in dev (Vite proxy handles it)
 	var staticFS fs.FS
@@ -67,15 +97,38 @@ func main() {
 	// Repositories
 	playerRepo := repository.NewPlayerRepo(pool)
 	matchRepo := repository.NewMatchRepo(pool)
+	squadRepo := repository.NewSquadRepo(pool)
+	trackedRepo := repository.NewTrackedRepo(pool)
+	backfillRepo := repository.NewBackfillRepo(pool)
+	tokenRepo := repository.NewTokenRepo(pool)
 
 	// Services
 	playerService := service.NewPlayerService(cachedAPI, playerRepo)
 	matchService := service.NewMatchService(cachedAPI, matchRepo, playerRepo)
+	squadService := service.NewSquadService(cachedAPI, squadRepo, playerRepo, playerService)
+	trackingService := service.NewTrackingService(trackedRepo, playerRepo, playerService, matchService)
+	backfillService := service.NewBackfillService(cachedAPI, matchRepo, playerRepo, backfillRepo)
+	reprocessService := service.NewReprocessService(matchRepo, playerRepo)
+	tokenService, err := service.NewTokenService(cachedAPI, tokenRepo, cfg.TokenKey)
+	if err != nil {
+		slog.Error("failed to create token service", "error", err)
+		os.Exit(1)
+	}
+	if err := tokenService.Load(ctx); err != nil {
+		slog.Error("failed to load stored sso tokens", "error", err)
+		os.Exit(1)
+	}
 
 	// Handlers
-	adminHandler := handler.NewAdminHandler(cachedAPI)
+	healthHandler := handler.NewHealthHandler(cachedAPI)
+	adminHandler := handler.NewAdminHandler(tokenService)
 	playerHandler := handler.NewPlayerHandler(playerService)
 	matchHandler := handler.NewMatchHandler(matchService)
+	squadHandler := handler.NewSquadHandler(squadService)
+	trackingHandler := handler.NewTrackingHandler(trackingService)
+	backfillHandler := handler.NewBackfillHandler(backfillService)
+	cacheHandler := handler.NewCacheHandler(cachedAPI)
+	reprocessHandler := handler.NewReprocessHandler(reprocessService)
 
 	// Router
 	rawOrigins := strings.Split(cfg.CORSAllowedOrigins, ",")
@@ -86,10 +139,16 @@ func main() {
 		}
 	}
 	mux := router.New(origins, staticFS, router.Deps{
-		AdminHandler:  adminHandler,
-		PlayerHandler: playerHandler,
-		MatchHandler:  matchHandler,
-		AdminAPIKey:   cfg.AdminAPIKey,
+		HealthHandler:    healthHandler,
+		AdminHandler:     adminHandler,
+		PlayerHandler:    playerHandler,
+		MatchHandler:     matchHandler,
+		SquadHandler:     squadHandler,
+		TrackingHandler:  trackingHandler,
+		BackfillHandler:  backfillHandler,
+		CacheHandler:     cacheHandler,
+		ReprocessHandler: reprocessHandler,
+		AdminAPIKey:      cfg.AdminAPIKey,
 	})
 
 	srv := &http.Server{
@@ -112,16 +171,43 @@ func main() {
 		}
 	}()
 
+	// Background workers: tracker for followed players and SSO token change listener
+	bgCtx, stopBackground := context.WithCancel(ctx)
+	var bgWG sync.WaitGroup
+	bgWG.Add(2)
+	go func() {
+		defer bgWG.Done()
+		tracker.New(trackingService, cfg.TrackerInterval, cfg.TrackerJitter).Run(bgCtx)
+	}()
+	go func() {
+		defer bgWG.Done()
+		tokenService.Listen(bgCtx)
+	}()
+	bgDone := make(chan struct{})
+	go func() {
+		bgWG.Wait()
+		close(bgDone)
+	}()
+
 	<-done
 	slog.Info("shutting down server")
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	stopBackground()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		slog.Error("server shutdown failed", "error", err)
 		os.Exit(1)
 	}
 
+	select {
+	case <-bgDone:
+	case <-shutdownCtx.Done():
+		slog.Warn("background workers did not stop before shutdown deadline")
+	}
+	backfillService.Shutdown(shutdownCtx)
+	reprocessService.Shutdown(shutdownCtx)
+
 	slog.Info("server stopped")
 }
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
 )
 
 // isTransientError returns true for errors where serving stale cache is appropriate.
@@ -16,95 +19,295 @@ func isTransientError(err error) bool {
 	return errors.Is(err, codclient.ErrAPIUnavailable) || errors.Is(err, codclient.ErrRateLimited)
 }
 
-type entry struct {
-	value     any
-	createdAt time.Time
-	expiresAt time.Time
+// isNegativeError returns true for lookups whose failure is worth remembering, so
+// repeated requests for a missing or private player don't reach the upstream API.
+func isNegativeError(err error) bool {
+	return errors.Is(err, codclient.ErrPlayerNotFound) || errors.Is(err, codclient.ErrPrivateProfile)
 }
 
-func (e entry) isExpired() bool {
-	return time.Now().After(e.expiresAt)
+// Negative entries store one of these codes in place of a value.
+const (
+	negativeNotFound = "player_not_found"
+	negativePrivate  = "private_profile"
+)
+
+// revalidateBackoff delays the next background refresh after one fails, so a struggling
+// upstream isn't retried on every request inside the soft-expired window.
+const revalidateBackoff = 30 * time.Second
+
+type revalidateKey struct{}
+
+// WithRevalidate returns a context whose lookups won't settle for a soft-expired entry:
+// it is refetched synchronously, as on a miss, instead of served while a background
+// refresh runs. Fresh entries are still served. Callers that persist what they read,
+// like the tracker, use it so they see each upstream change rather than a stale copy.
+func WithRevalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
 }
 
-// CachedClient wraps a CodClient with in-process TTL caching.
+func revalidating(ctx context.Context) bool {
+	v, _ := ctx.Value(revalidateKey{}).(bool)
+	return v
+}
+
+// CachedClient wraps a CodClient with TTL caching over a pluggable Store.
 type CachedClient struct {
-	inner     codclient.CodClient
-	mu        sync.RWMutex
-	store     map[string]entry
-	statsTTL  time.Duration
-	matchTTL  time.Duration
+	inner       codclient.CodClient
+	store       Store
+	statsTTL    ttl
+	matchTTL    ttl
+	notFoundTTL time.Duration
+	privateTTL  time.Duration
+	inflight    singleflight.Group
+
+	hits         atomic.Int64
+	misses       atomic.Int64
+	staleServes  atomic.Int64
+	negativeHits atomic.Int64
 }
 
-// Config holds cache TTL settings.
+type ttl struct {
+	soft time.Duration
+	hard time.Duration
+}
+
+// Config holds cache TTL settings. Within the soft TTL an entry is fresh; between the
+// soft and hard TTL it is returned immediately while a single background refresh runs;
+// past the hard TTL callers wait for a synchronous fetch. MaxEntries and MaxBytes bound
+// the default memory store; zero means unbounded. NotFoundTTL and PrivateTTL control how
+// long a player lookup failing with ErrPlayerNotFound or ErrPrivateProfile is remembered;
+// zero disables negative caching for that error.
 type Config struct {
-	StatsTTL time.Duration
-	MatchTTL time.Duration
+	StatsSoftTTL time.Duration
+	StatsHardTTL time.Duration
+	MatchSoftTTL time.Duration
+	MatchHardTTL time.Duration
+	MaxEntries   int
+	MaxBytes     int64
+	NotFoundTTL  time.Duration
+	PrivateTTL   time.Duration
 }
 
 func DefaultConfig() Config {
 	return Config{
-		StatsTTL: 5 * time.Minute,
-		MatchTTL: 2 * time.Minute,
+		StatsSoftTTL: 5 * time.Minute,
+		StatsHardTTL: 1 * time.Hour,
+		MatchSoftTTL: 2 * time.Minute,
+		MatchHardTTL: 30 * time.Minute,
+		MaxEntries:   10000,
+		MaxBytes:     64 << 20,
+		NotFoundTTL:  2 * time.Minute,
+		PrivateTTL:   10 * time.Minute,
 	}
 }
 
-// New wraps a CodClient with caching.
-func New(inner codclient.CodClient, cfg Config) *CachedClient {
-	c := &CachedClient{
-		inner:    inner,
-		store:    make(map[string]entry),
-		statsTTL: cfg.StatsTTL,
-		matchTTL: cfg.MatchTTL,
+// New wraps a CodClient with caching. A nil store falls back to a MemoryStore bounded
+// by cfg.MaxEntries and cfg.MaxBytes.
+func New(inner codclient.CodClient, store Store, cfg Config) *CachedClient {
+	if store == nil {
+		store = NewMemoryStore(cfg.MaxEntries, cfg.MaxBytes)
+	}
+	return &CachedClient{
+		inner:       inner,
+		store:       store,
+		statsTTL:    ttl{soft: cfg.StatsSoftTTL, hard: max(cfg.StatsSoftTTL, cfg.StatsHardTTL)},
+		matchTTL:    ttl{soft: cfg.MatchSoftTTL, hard: max(cfg.MatchSoftTTL, cfg.MatchHardTTL)},
+		notFoundTTL: cfg.NotFoundTTL,
+		privateTTL:  cfg.PrivateTTL,
 	}
-	go c.evictLoop()
-	return c
 }
 
 func (c *CachedClient) GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
-	key := fmt.Sprintf("stats:%s:%s:%s:%s", platform, gamertag, title, mode)
+	key := playerKey("stats", platform, gamertag, title, mode)
+	if err := c.getNegative(ctx, platform, gamertag, title, mode); err != nil {
+		return nil, err
+	}
+
+	val, err := c.cached(ctx, key, func(ctx context.Context) (any, error) {
+		stats, err := c.inner.GetPlayerStats(ctx, platform, gamertag, title, mode)
+		if err != nil {
+			c.setNegative(ctx, platform, gamertag, title, mode, err)
+			return nil, err
+		}
+		c.set(ctx, key, stats, c.statsTTL)
+		return stats, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decode[*codclient.PlayerStats](val)
+}
+
+func (c *CachedClient) GetRecentMatches(ctx context.Context, platform, gamertag, title, mode string) ([]codclient.Match, error) {
+	key := playerKey("matches", platform, gamertag, title, mode)
+	if err := c.getNegative(ctx, platform, gamertag, title, mode); err != nil {
+		return nil, err
+	}
 
-	if val, hit := c.get(key); hit {
-		slog.Debug("cache hit", "key", key)
-		return val.(*codclient.PlayerStats), nil
+	val, err := c.cached(ctx, key, func(ctx context.Context) (any, error) {
+		matches, err := c.inner.GetRecentMatches(ctx, platform, gamertag, title, mode)
+		if err != nil {
+			c.setNegative(ctx, platform, gamertag, title, mode, err)
+			return nil, err
+		}
+		c.set(ctx, key, matches, c.matchTTL)
+		return matches, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return decode[[]codclient.Match](val)
+}
 
-	stats, err := c.inner.GetPlayerStats(ctx, platform, gamertag, title, mode)
+// cached serves key from the cache, revalidating soft-expired entries in the background,
+// or in the foreground under WithRevalidate. On a miss it calls load, which must store
+// its result; if load fails with a transient error, any stale entry is served instead.
+// Where the value came from is reported to any freshness.Recorder on ctx.
+func (c *CachedClient) cached(ctx context.Context, key string, load func(ctx context.Context) (any, error)) (any, error) {
+	if e, fresh, hit := c.get(ctx, key); hit && (fresh || !revalidating(ctx)) {
+		if fresh {
+			c.hits.Add(1)
+			freshness.Record(ctx, freshness.Cache, e.CreatedAt)
+			slog.Debug("cache hit", "key", key)
+		} else {
+			c.staleServes.Add(1)
+			freshness.Record(ctx, freshness.StaleCache, e.CreatedAt)
+			slog.Debug("cache hit (stale), revalidating", "key", key)
+			c.revalidate(ctx, key, load)
+		}
+		return e.Value, nil
+	}
+
+	c.misses.Add(1)
+	val, err := c.fetch(ctx, key, load)
 	if err != nil {
 		// Only serve stale data for transient errors (API down, rate limited)
 		if isTransientError(err) {
-			if val, ok := c.getStale(key); ok {
+			if e, ok := c.getStale(ctx, key); ok {
+				c.staleServes.Add(1)
+				freshness.Record(ctx, freshness.StaleCache, e.CreatedAt)
 				slog.Warn("serving stale cache due to API error", "key", key, "error", err)
-				return val.(*codclient.PlayerStats), nil
+				return e.Value, nil
 			}
 		}
 		return nil, err
 	}
+	freshness.Record(ctx, freshness.Live, time.Now())
+	return val, nil
+}
+
+// fetch runs fn once for all concurrent callers with the same key, so a burst of
+// misses costs a single upstream request. The shared call runs detached from any one
+// caller's cancellation; a caller whose ctx ends stops waiting without aborting it.
+func (c *CachedClient) fetch(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	ch := c.inflight.DoChan(key, func() (any, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		if res.Shared {
+			slog.Debug("coalesced upstream fetch", "key", key)
+		}
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
 
-	c.set(key, stats, c.statsTTL)
-	return stats, nil
+// revalidate starts a background refresh of key without waiting for it. It shares the
+// in-flight group with fetch, so at most one upstream request per key runs at a time.
+func (c *CachedClient) revalidate(ctx context.Context, key string, load func(ctx context.Context) (any, error)) {
+	c.inflight.DoChan(key, func() (any, error) {
+		// The caller already has a stale value, so the refresh yields to interactive requests
+		val, err := load(codclient.WithPriority(context.WithoutCancel(ctx), codclient.PriorityBackground))
+		if err != nil {
+			slog.Warn("background cache refresh failed", "key", key, "error", err)
+			c.deferStale(context.WithoutCancel(ctx), key, revalidateBackoff)
+		}
+		return val, err
+	})
 }
 
-func (c *CachedClient) GetRecentMatches(ctx context.Context, platform, gamertag, title, mode string) ([]codclient.Match, error) {
-	key := fmt.Sprintf("matches:%s:%s:%s:%s", platform, gamertag, title, mode)
+// ClearNegative forgets a cached not-found or private result for a player in one title and
+// mode, so the next request goes to the upstream API. It reports whether an entry was present.
+func (c *CachedClient) ClearNegative(ctx context.Context, platform, gamertag, title, mode string) (bool, error) {
+	key := negativeKey(platform, gamertag, title, mode)
+	_, ok, err := c.store.Get(ctx, key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := c.store.Delete(ctx, key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	if val, hit := c.get(key); hit {
-		slog.Debug("cache hit", "key", key)
-		return val.([]codclient.Match), nil
+// playerKey identifies a per-player entry of kind. The title and mode are resolved to
+// their defaults so an unqualified lookup and one naming them share an entry.
+func playerKey(kind, platform, gamertag, title, mode string) string {
+	if t, m, err := codclient.ResolveTitle(title, mode); err == nil {
+		title, mode = t.ID, m
 	}
+	return fmt.Sprintf("%s:%s:%s:%s:%s", kind, platform, gamertag, title, mode)
+}
+
+// negativeKey identifies a negative entry. A profile can be private or missing in one title
+// but not another, so the title and mode are part of the key.
+func negativeKey(platform, gamertag, title, mode string) string {
+	return playerKey("negative", platform, gamertag, title, mode)
+}
 
-	matches, err := c.inner.GetRecentMatches(ctx, platform, gamertag, title, mode)
+// getNegative returns the sentinel error remembered for a player, or nil if the player
+// has no live negative entry for the title and mode.
+func (c *CachedClient) getNegative(ctx context.Context, platform, gamertag, title, mode string) error {
+	key := negativeKey(platform, gamertag, title, mode)
+	e, ok := c.lookup(ctx, key)
+	if !ok || e.isExpired() {
+		return nil
+	}
+	code, err := decode[string](e.Value)
 	if err != nil {
-		if isTransientError(err) {
-			if val, ok := c.getStale(key); ok {
-				slog.Warn("serving stale cache due to API error", "key", key, "error", err)
-				return val.([]codclient.Match), nil
-			}
-		}
-		return nil, err
+		slog.Warn("ignoring undecodable negative cache entry", "key", key, "error", err)
+		return nil
+	}
+
+	c.negativeHits.Add(1)
+	slog.Debug("negative cache hit", "key", key, "code", code)
+	switch code {
+	case negativePrivate:
+		return codclient.ErrPrivateProfile
+	default:
+		return codclient.ErrPlayerNotFound
+	}
+}
+
+// setNegative remembers a not-found or private result for a player in a title and mode.
+// Other errors are ignored.
+func (c *CachedClient) setNegative(ctx context.Context, platform, gamertag, title, mode string, err error) {
+	if !isNegativeError(err) {
+		return
+	}
+	code, d := negativeNotFound, c.notFoundTTL
+	if errors.Is(err, codclient.ErrPrivateProfile) {
+		code, d = negativePrivate, c.privateTTL
+	}
+	if d <= 0 {
+		return
 	}
+	c.set(ctx, negativeKey(platform, gamertag, title, mode), code, ttl{soft: d, hard: d})
+}
+
+// GetMatchesRange passes through to the inner client. Historical pages are fetched
+// once by backfill jobs, so caching them would only cost memory.
+func (c *CachedClient) GetMatchesRange(ctx context.Context, platform, gamertag, title, mode string, start, end time.Time) ([]codclient.Match, error) {
+	return c.inner.GetMatchesRange(ctx, platform, gamertag, title, mode, start, end)
+}
 
-	c.set(key, matches, c.matchTTL)
-	return matches, nil
+// GetMatchDetails passes through to the inner client. Finished matches never change,
+// so they are persisted in the database rather than cached here.
+func (c *CachedClient) GetMatchDetails(ctx context.Context, title, platform, matchID string) (*codclient.MatchDetails, error) {
+	return c.inner.GetMatchDetails(ctx, title, platform, matchID)
 }
 
 // UpdateToken passes through to the inner client.
@@ -112,71 +315,123 @@ func (c *CachedClient) UpdateToken(newToken string) {
 	c.inner.UpdateToken(newToken)
 }
 
-// CacheInfo returns hit/miss status and age for use in response headers.
-func (c *CachedClient) CacheInfo(key string) (hit bool, ageSeconds int, stale bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// AddToken passes through to the inner client.
+func (c *CachedClient) AddToken(name, token string) {
+	c.inner.AddToken(name, token)
+}
+
+// RemoveToken passes through to the inner client.
+func (c *CachedClient) RemoveToken(name string) bool {
+	return c.inner.RemoveToken(name)
+}
+
+// ListTokens passes through to the inner client.
+func (c *CachedClient) ListTokens() []codclient.TokenInfo {
+	return c.inner.ListTokens()
+}
+
+// CircuitStates passes through to the inner client.
+func (c *CachedClient) CircuitStates() []codclient.CircuitInfo {
+	return c.inner.CircuitStates()
+}
 
-	e, exists := c.store[key]
+// LimiterStats passes through to the inner client.
+func (c *CachedClient) LimiterStats() codclient.LimiterStats {
+	return c.inner.LimiterStats()
+}
+
+// CacheInfo returns hit/miss status and age for use in response headers. Stale
+// reports whether the entry is past its soft TTL.
+func (c *CachedClient) CacheInfo(key string) (hit bool, ageSeconds int, stale bool) {
+	e, exists := c.lookup(context.Background(), key)
 	if !exists {
 		return false, 0, false
 	}
 
-	age := int(time.Since(e.createdAt).Seconds())
+	age := int(time.Since(e.CreatedAt).Seconds())
 	if age < 0 {
 		age = 0
 	}
 
-	return true, age, e.isExpired()
+	return true, age, e.isStale()
 }
 
-func (c *CachedClient) get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// Stats is a snapshot of cache counters. StaleServes counts both soft-expired hits and
+// stale fallbacks served during upstream errors; NegativeHits counts requests answered
+// from a cached not-found or private result.
+type Stats struct {
+	Hits         int64      `json:"hits"`
+	Misses       int64      `json:"misses"`
+	StaleServes  int64      `json:"staleServes"`
+	NegativeHits int64      `json:"negativeHits"`
+	Evictions    int64      `json:"evictions"`
+	Store        StoreStats `json:"store"`
+}
 
-	e, ok := c.store[key]
-	if !ok || e.isExpired() {
-		return nil, false
+// Stats returns the cache's counters along with the underlying store's size.
+func (c *CachedClient) Stats(ctx context.Context) (Stats, error) {
+	store, err := c.store.Stats(ctx)
+	if err != nil {
+		return Stats{}, err
 	}
-	return e.value, true
+	return Stats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		StaleServes:  c.staleServes.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Evictions:    store.Evictions,
+		Store:        store,
+	}, nil
 }
 
-func (c *CachedClient) getStale(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// lookup reads key from the store. Store errors are logged and treated as a miss so a
+// shared backend outage degrades to uncached requests rather than failures.
+func (c *CachedClient) lookup(ctx context.Context, key string) (Entry, bool) {
+	e, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		slog.Warn("cache store read failed", "key", key, "error", err)
+		return Entry{}, false
+	}
+	return e, ok
+}
 
-	e, ok := c.store[key]
-	if !ok {
-		return nil, false
+// get returns an entry within its hard TTL, reporting whether it is still fresh.
+func (c *CachedClient) get(ctx context.Context, key string) (e Entry, fresh bool, hit bool) {
+	e, ok := c.lookup(ctx, key)
+	if !ok || e.isExpired() {
+		return Entry{}, false, false
 	}
-	return e.value, true
+	return e, !e.isStale(), true
 }
 
-func (c *CachedClient) set(key string, value any, ttl time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *CachedClient) getStale(ctx context.Context, key string) (Entry, bool) {
+	return c.lookup(ctx, key)
+}
 
+func (c *CachedClient) set(ctx context.Context, key string, value any, t ttl) {
 	now := time.Now()
-	c.store[key] = entry{
-		value:     value,
-		createdAt: now,
-		expiresAt: now.Add(ttl),
+	err := c.store.Set(ctx, key, Entry{
+		Value:     value,
+		CreatedAt: now,
+		StaleAt:   now.Add(t.soft),
+		ExpiresAt: now.Add(t.hard),
+	})
+	if err != nil {
+		slog.Warn("cache store write failed", "key", key, "error", err)
 	}
 }
 
-func (c *CachedClient) evictLoop() {
-	ticker := time.NewTicker(10 * time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		c.mu.Lock()
-		// Evict entries that have been stale for more than 1 hour
-		cutoff := time.Now().Add(-1 * time.Hour)
-		for k, e := range c.store {
-			if e.expiresAt.Before(cutoff) {
-				delete(c.store, k)
-			}
-		}
-		c.mu.Unlock()
+// deferStale pushes an entry's soft expiry out by d, capped at its hard expiry.
+func (c *CachedClient) deferStale(ctx context.Context, key string, d time.Duration) {
+	e, ok := c.lookup(ctx, key)
+	if !ok {
+		return
+	}
+	e.StaleAt = time.Now().Add(d)
+	if e.StaleAt.After(e.ExpiresAt) {
+		e.StaleAt = e.ExpiresAt
+	}
+	if err := c.store.Set(ctx, key, e); err != nil {
+		slog.Warn("cache store write failed", "key", key, "error", err)
 	}
 }
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore is a Store shared by every replica, kept in the UNLOGGED cache_entries
+// table so warm entries survive restarts and deploys. Values are stored as JSON and
+// returned as json.RawMessage.
+type PostgresStore struct {
+	pool      *pgxpool.Pool
+	evictions atomic.Int64
+}
+
+// NewPostgresStore creates a PostgresStore and starts its eviction loop.
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	s := &PostgresStore{pool: pool}
+	go s.evictLoop()
+	return s
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	var e Entry
+	var raw []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT value, created_at, stale_at, expires_at FROM cache_entries WHERE key = $1
+	`, key).Scan(&raw, &e.CreatedAt, &e.StaleAt, &e.ExpiresAt)
+	if err == pgx.ErrNoRows {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	e.Value = json.RawMessage(raw)
+	return e, true, nil
+}
+
+func (s *PostgresStore) Set(ctx context.Context, key string, e Entry) error {
+	raw, err := json.Marshal(e.Value)
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO cache_entries (key, value, created_at, stale_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, created_at = EXCLUDED.created_at,
+			stale_at = EXCLUDED.stale_at, expires_at = EXCLUDED.expires_at
+	`, key, raw, e.CreatedAt, e.StaleAt, e.ExpiresAt)
+	return err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM cache_entries WHERE key = $1`, key)
+	return err
+}
+
+func (s *PostgresStore) Stats(ctx context.Context) (StoreStats, error) {
+	stats := StoreStats{Backend: "postgres", Evictions: s.evictions.Load()}
+	err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM cache_entries`).Scan(&stats.Entries)
+	return stats, err
+}
+
+func (s *PostgresStore) evictLoop() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		tag, err := s.pool.Exec(ctx, `DELETE FROM cache_entries WHERE expires_at < $1`,
+			time.Now().Add(-evictAfter))
+		cancel()
+		if err != nil {
+			slog.Warn("failed to evict expired cache entries", "error", err)
+			continue
+		}
+		if n := tag.RowsAffected(); n > 0 {
+			s.evictions.Add(n)
+			slog.Debug("evicted expired cache entries", "count", n)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+// evictAfter is how long past its hard TTL an entry is kept as an error fallback.
+const evictAfter = 1 * time.Hour
+
+// Entry is a cached value with its freshness bounds.
+type Entry struct {
+	Value     any
+	CreatedAt time.Time
+	StaleAt   time.Time // soft TTL: after this the value is served while refreshing
+	ExpiresAt time.Time // hard TTL: after this the value is only used as an error fallback
+}
+
+func (e Entry) isStale() bool {
+	return time.Now().After(e.StaleAt)
+}
+
+func (e Entry) isExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// StoreStats describes the contents of a cache store. Bytes is approximate and only
+// reported by backends that track it.
+type StoreStats struct {
+	Backend   string `json:"backend"`
+	Entries   int    `json:"entries"`
+	Bytes     int64  `json:"bytes,omitempty"`
+	Evictions int64  `json:"evictions"`
+}
+
+// Store is a cache backend. Implementations may return Values as json.RawMessage
+// rather than the type that was stored; CachedClient decodes them on read.
+type Store interface {
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	Set(ctx context.Context, key string, e Entry) error
+	Delete(ctx context.Context, key string) error
+	Stats(ctx context.Context) (StoreStats, error)
+}
+
+// MemoryStore is a process-local Store. When bounded by entry count or approximate byte
+// size, inserts evict the least recently used entries until the store fits again.
+type MemoryStore struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	bytes      int64
+	maxEntries int
+	maxBytes   int64
+	evictions  atomic.Int64
+}
+
+type memoryItem struct {
+	key   string
+	entry Entry
+	size  int64
+}
+
+// NewMemoryStore creates a MemoryStore and starts its eviction loop. A zero maxEntries
+// or maxBytes leaves that dimension unbounded.
+func NewMemoryStore(maxEntries int, maxBytes int64) *MemoryStore {
+	s := &MemoryStore{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+	}
+	go s.evictLoop()
+	return s
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+	s.lru.MoveToFront(el)
+	return el.Value.(*memoryItem).entry, true, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, e Entry) error {
+	size := approxSize(key, e.Value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		item := el.Value.(*memoryItem)
+		s.bytes += size - item.size
+		item.entry, item.size = e, size
+		s.lru.MoveToFront(el)
+	} else {
+		s.entries[key] = s.lru.PushFront(&memoryItem{key: key, entry: e, size: size})
+		s.bytes += size
+	}
+
+	// Never evict the entry just written, even if it alone exceeds the byte budget
+	for s.lru.Len() > 1 && s.overBudget() {
+		s.removeElement(s.lru.Back())
+		s.evictions.Add(1)
+	}
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.removeElement(el)
+	}
+	return nil
+}
+
+func (s *MemoryStore) Stats(_ context.Context) (StoreStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return StoreStats{
+		Backend:   "memory",
+		Entries:   len(s.entries),
+		Bytes:     s.bytes,
+		Evictions: s.evictions.Load(),
+	}, nil
+}
+
+func (s *MemoryStore) overBudget() bool {
+	return (s.maxEntries > 0 && s.lru.Len() > s.maxEntries) ||
+		(s.maxBytes > 0 && s.bytes > s.maxBytes)
+}
+
+// removeElement unlinks an item from the map and list. Callers must hold mu.
+func (s *MemoryStore) removeElement(el *list.Element) {
+	item := s.lru.Remove(el).(*memoryItem)
+	delete(s.entries, item.key)
+	s.bytes -= item.size
+}
+
+func (s *MemoryStore) evictLoop() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		// Evict entries that have been past their hard TTL for more than evictAfter
+		cutoff := time.Now().Add(-evictAfter)
+		for _, el := range s.entries {
+			if el.Value.(*memoryItem).entry.ExpiresAt.Before(cutoff) {
+				s.removeElement(el)
+				s.evictions.Add(1)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// approxSize estimates an entry's memory footprint from its JSON encoding. It's only
+// used for budgeting, so values that fail to encode count for their key alone.
+func approxSize(key string, v any) int64 {
+	if raw, ok := v.(json.RawMessage); ok {
+		return int64(len(key) + len(raw))
+	}
+	size := int64(len(key))
+	// The upstream payload isn't part of the stats JSON encoding but is held in memory
+	if stats, ok := v.(*codclient.PlayerStats); ok && stats != nil {
+		size += int64(len(stats.RawData))
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return size
+	}
+	return size + int64(len(raw))
+}
+
+// decode converts a stored value to T, unmarshalling it if the store returned raw JSON.
+func decode[T any](v any) (T, error) {
+	switch val := v.(type) {
+	case T:
+		return val, nil
+	case json.RawMessage:
+		var out T
+		if err := json.Unmarshal(val, &out); err != nil {
+			return out, fmt.Errorf("decoding cached value: %w", err)
+		}
+		return out, nil
+	default:
+		var zero T
+		return zero, fmt.Errorf("unexpected cached value type %T", v)
+	}
+}
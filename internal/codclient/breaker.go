@@ -0,0 +1,157 @@
+package codclient
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerThreshold is how many consecutive outage failures open a circuit.
+	breakerThreshold = 5
+
+	// breakerCooldown is how long an open circuit fails fast before allowing a probe.
+	breakerCooldown = 30 * time.Second
+)
+
+// Endpoint families, each guarded by its own circuit.
+const (
+	familyProfile = "profile"
+	familyMatches = "matches"
+)
+
+// Circuit states reported by CircuitStates.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// CircuitInfo describes the state of one endpoint family's circuit breaker.
+type CircuitInfo struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+}
+
+// breaker fails requests fast while the upstream is down. After threshold consecutive
+// outage failures it opens; once cooldown has passed it lets a single probe through
+// (half-open) and closes again if the probe succeeds.
+type breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(name string, threshold int, cooldown time.Duration) *breaker {
+	return &breaker{name: name, threshold: threshold, cooldown: cooldown, state: CircuitClosed}
+}
+
+// allow reports whether a request may proceed. Every allowed request must be followed
+// by a call to record.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrAPIUnavailable
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		slog.Info("cod api circuit half-open, probing", "circuit", b.name)
+		return nil
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrAPIUnavailable
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit with a request's outcome. Only outage errors count as
+// failures; anything else shows the upstream is reachable.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !errors.Is(err, ErrAPIUnavailable) {
+		if b.state != CircuitClosed {
+			slog.Info("cod api circuit closed", "circuit", b.name)
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		if b.state != CircuitOpen {
+			slog.Warn("cod api circuit opened", "circuit", b.name, "consecutive_failures", b.failures)
+		}
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+// abandon releases a probe whose outcome is unknown, such as one cancelled by its
+// caller, so the next request probes instead.
+func (b *breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) info() CircuitInfo {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	info := CircuitInfo{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != CircuitClosed {
+		opened := b.openedAt
+		retry := b.openedAt.Add(b.cooldown)
+		info.OpenedAt = &opened
+		info.RetryAt = &retry
+	}
+	return info
+}
+
+// breakerSet holds one breaker per endpoint family.
+type breakerSet map[string]*breaker
+
+func newBreakerSet(threshold int, cooldown time.Duration, families ...string) breakerSet {
+	set := make(breakerSet, len(families))
+	for _, f := range families {
+		set[f] = newBreaker(f, threshold, cooldown)
+	}
+	return set
+}
+
+func (s breakerSet) list() []CircuitInfo {
+	infos := make([]CircuitInfo, 0, len(s))
+	for _, b := range s {
+		infos = append(infos, b.info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
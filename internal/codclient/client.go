@@ -3,12 +3,12 @@ package codclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"resty.dev/v3"
@@ -18,76 +18,114 @@ import (
 type CodClient interface {
 	GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*PlayerStats, error)
 	GetRecentMatches(ctx context.Context, platform, gamertag, title, mode string) ([]Match, error)
+	GetMatchesRange(ctx context.Context, platform, gamertag, title, mode string, start, end time.Time) ([]Match, error)
+	GetMatchDetails(ctx context.Context, title, platform, matchID string) (*MatchDetails, error)
 	UpdateToken(newToken string)
+	AddToken(name, token string)
+	RemoveToken(name string) bool
+	ListTokens() []TokenInfo
+	CircuitStates() []CircuitInfo
+	LimiterStats() LimiterStats
 }
 
 type client struct {
-	http    *resty.Client
-	baseURL string
-	mu      sync.RWMutex
-	token   string
+	http      *resty.Client
+	baseURL   string
+	tokens    *tokenPool
+	breakers  breakerSet
+	limiter   *limiter
+	retryWait time.Duration
 }
 
-// New creates a new CoD API client.
-func New(baseURL, ssoToken string) CodClient {
+// New creates a new CoD API client whose outbound requests share the rl budget. A
+// rate-limited token sits out for the upstream's Retry-After, or tokenCooldown when it
+// sends none; zero means defaultTokenCooldown.
+func New(baseURL, ssoToken string, rl RateLimit, tokenCooldown time.Duration) CodClient {
 	c := resty.New()
 	c.SetBaseURL(baseURL)
 	c.SetTimeout(10 * time.Second)
-	c.SetRetryCount(3)
-	c.SetRetryWaitTime(1 * time.Second)
-	c.SetRetryMaxWaitTime(5 * time.Second)
+	// Retries happen in doRequest so each attempt passes the limiter and circuit breaker
+	c.SetRetryCount(0)
 	c.SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	c.SetHeader("Accept", "application/json")
 	c.SetRedirectPolicy(resty.NoRedirectPolicy())
 
-	return &client{http: c, baseURL: baseURL, token: ssoToken}
-}
+	if tokenCooldown <= 0 {
+		tokenCooldown = defaultTokenCooldown
+	}
+	tokens := newTokenPool(tokenCooldown)
+	if ssoToken != "" {
+		tokens.put(DefaultTokenName, ssoToken)
+	}
 
-func (c *client) authCookie() *http.Cookie {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return &http.Cookie{Name: "ACT_SSO_COOKIE", Value: c.token}
+	return &client{
+		http:      c,
+		baseURL:   baseURL,
+		tokens:    tokens,
+		breakers:  newBreakerSet(breakerThreshold, breakerCooldown, familyProfile, familyMatches),
+		limiter:   newLimiter(rl),
+		retryWait: retryWaitTime,
+	}
 }
 
 func (c *client) GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*PlayerStats, error) {
-	if title == "" {
-		title = "mw"
-	}
-	if mode == "" {
-		mode = "wz"
+	t, mode, err := ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
 	}
 
 	encodedTag := url.PathEscape(gamertag)
 	endpoint := fmt.Sprintf("/stats/cod/v1/title/%s/platform/%s/gamer/%s/profile/type/%s",
-		title, platform, encodedTag, mode)
+		t.APICode, platform, encodedTag, mode)
+
+	resp, err := c.doRequest(ctx, familyProfile, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(resp.Bytes(), t, platform, gamertag)
+}
 
-	resp, err := c.doRequest(ctx, endpoint)
+// ParseProfile maps a raw profile endpoint payload for title to PlayerStats, keeping a
+// copy of the payload in RawData. It is used both for live responses and to reprocess
+// stored ones; an empty title means DefaultTitle.
+func ParseProfile(raw []byte, title, platform, gamertag string) (*PlayerStats, error) {
+	t, _, err := ResolveTitle(title, "")
 	if err != nil {
 		return nil, err
 	}
+	return parseProfile(raw, t, platform, gamertag)
+}
 
+func parseProfile(raw []byte, t Title, platform, gamertag string) (*PlayerStats, error) {
 	var profileResp profileResponse
-	if err := json.Unmarshal(resp.Bytes(), &profileResp); err != nil {
+	if err := json.Unmarshal(raw, &profileResp); err != nil {
 		return nil, fmt.Errorf("decoding profile response: %w", err)
 	}
 
-	stats := c.mapProfileToStats(profileResp, platform, gamertag)
+	stats := mapProfileToStats(profileResp, t.Fields, platform, gamertag)
+	stats.RawData = append(json.RawMessage(nil), raw...)
 	return stats, nil
 }
 
 func (c *client) GetRecentMatches(ctx context.Context, platform, gamertag, title, mode string) ([]Match, error) {
-	if title == "" {
-		title = "mw"
-	}
-	if mode == "" {
-		mode = "wz"
+	return c.GetMatchesRange(ctx, platform, gamertag, title, mode, time.Time{}, time.Time{})
+}
+
+// GetMatchesRange fetches the page of matches played between start and end. A zero
+// start or end leaves that side of the window open, so passing only end walks
+// backwards through history one page at a time.
+func (c *client) GetMatchesRange(ctx context.Context, platform, gamertag, title, mode string, start, end time.Time) ([]Match, error) {
+	t, mode, err := ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
 	}
 
 	encodedTag := url.PathEscape(gamertag)
-	endpoint := fmt.Sprintf("/crm/cod/v2/title/%s/platform/%s/gamer/%s/matches/%s/start/0/end/0/details",
-		title, platform, encodedTag, mode)
+	endpoint := fmt.Sprintf("/crm/cod/v2/title/%s/platform/%s/gamer/%s/matches/%s/start/%d/end/%d/details",
+		t.APICode, platform, encodedTag, mode, epochMillis(start), epochMillis(end))
 
-	resp, err := c.doRequest(ctx, endpoint)
+	resp, err := c.doRequest(ctx, familyMatches, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -99,45 +137,226 @@ func (c *client) GetRecentMatches(ctx context.Context, platform, gamertag, title
 
 	matches := make([]Match, 0, len(matchResp.Data.Matches))
 	for _, m := range matchResp.Data.Matches {
-		gulag := ""
-		if m.PlayerStats.GulagKills > 0 {
-			gulag = "win"
-		} else if m.PlayerStats.GulagDeaths > 0 {
-			gulag = "loss"
-		}
+		matches = append(matches, mapMatch(m))
+	}
+
+	return matches, nil
+}
 
-		matches = append(matches, Match{
-			MatchID:     m.MatchID,
-			Mode:        m.Mode,
-			Map:         m.Map,
-			Placement:   m.PlayerStats.TeamPlacement,
-			Kills:       m.PlayerStats.Kills,
-			Deaths:      m.PlayerStats.Deaths,
-			KDRatio:     m.PlayerStats.KDRatio,
-			DamageDealt: m.PlayerStats.DamageDone,
-			DamageTaken: m.PlayerStats.DamageTaken,
-			GulagResult: gulag,
-			Duration:    m.Duration,
-			MatchTime:   time.Unix(int64(m.UTCStartSeconds), 0),
+// ParseMatch maps one raw entry of the matches endpoint's data.matches array to a Match.
+// It is used to reprocess stored payloads.
+func ParseMatch(raw []byte) (Match, error) {
+	var m matchData
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Match{}, fmt.Errorf("decoding match: %w", err)
+	}
+	return mapMatch(m), nil
+}
+
+func mapMatch(m matchData) Match {
+	gulag := ""
+	if m.PlayerStats.GulagKills > 0 {
+		gulag = "win"
+	} else if m.PlayerStats.GulagDeaths > 0 {
+		gulag = "loss"
+	}
+
+	return Match{
+		MatchID:     m.MatchID,
+		Mode:        m.Mode,
+		Map:         m.Map,
+		Placement:   int(m.PlayerStats.TeamPlacement),
+		Kills:       int(m.PlayerStats.Kills),
+		Deaths:      int(m.PlayerStats.Deaths),
+		KDRatio:     m.PlayerStats.KDRatio,
+		DamageDealt: int(m.PlayerStats.DamageDone),
+		DamageTaken: int(m.PlayerStats.DamageTaken),
+		GulagResult: gulag,
+		Duration:    m.Duration,
+		MatchTime:   time.Unix(int64(m.UTCStartSeconds), 0),
+		RawData:     m.RawData,
+
+		Headshots:         int(m.PlayerStats.Headshots),
+		Assists:           int(m.PlayerStats.Assists),
+		Score:             int(m.PlayerStats.Score),
+		TimePlayed:        int(m.PlayerStats.TimePlayed),
+		DistanceTraveled:  m.PlayerStats.DistanceTraveled,
+		PercentTimeMoving: m.PlayerStats.PercentTimeMoving,
+		Revives:           int(m.PlayerStats.ObjectiveReviver),
+		TeamWipes:         int(m.PlayerStats.ObjectiveTeamWiped),
+		CachesOpened:      int(m.PlayerStats.ObjectiveBrCacheOpen),
+		Contracts:         int(m.PlayerStats.ObjectiveBrMissionPickupTablet),
+		Cash:              int(m.PlayerStats.Cash),
+	}
+}
+
+// GetMatchDetails fetches every participant in a match from the fullMatch endpoint.
+func (c *client) GetMatchDetails(ctx context.Context, title, platform, matchID string) (*MatchDetails, error) {
+	t, mode, err := ResolveTitle(title, "")
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/crm/cod/v2/title/%s/platform/%s/fullMatch/%s/%s/en",
+		t.APICode, platform, mode, url.PathEscape(matchID))
+
+	resp, err := c.doRequest(ctx, familyMatches, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var fullResp fullMatchResponse
+	if err := json.Unmarshal(resp.Bytes(), &fullResp); err != nil {
+		return nil, fmt.Errorf("decoding full match response: %w", err)
+	}
+	if len(fullResp.Data.AllPlayers) == 0 {
+		return nil, ErrMatchNotFound
+	}
+
+	first := fullResp.Data.AllPlayers[0]
+	details := &MatchDetails{
+		MatchID:      matchID,
+		Mode:         first.Mode,
+		Map:          first.Map,
+		Duration:     first.Duration,
+		MatchTime:    time.Unix(int64(first.UTCStartSeconds), 0),
+		Participants: make([]MatchParticipant, 0, len(fullResp.Data.AllPlayers)),
+	}
+	for _, p := range fullResp.Data.AllPlayers {
+		details.Participants = append(details.Participants, MatchParticipant{
+			Username:    p.Player.Username,
+			Clantag:     p.Player.Clantag,
+			UnoID:       p.Player.Uno,
+			Team:        p.Player.Team,
+			Placement:   int(p.PlayerStats.TeamPlacement),
+			Kills:       int(p.PlayerStats.Kills),
+			Deaths:      int(p.PlayerStats.Deaths),
+			KDRatio:     p.PlayerStats.KDRatio,
+			DamageDealt: int(p.PlayerStats.DamageDone),
+			DamageTaken: int(p.PlayerStats.DamageTaken),
 		})
 	}
 
-	return matches, nil
+	return details, nil
+}
+
+// epochMillis converts t to the millisecond timestamps used by the matches endpoint,
+// mapping the zero time to 0 (unbounded).
+func epochMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
 }
 
-// UpdateToken replaces the SSO token at runtime (for admin token refresh).
+// UpdateToken replaces the default pool token at runtime (for admin token refresh).
 func (c *client) UpdateToken(newToken string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.token = newToken
+	c.tokens.put(DefaultTokenName, newToken)
 	slog.Info("cod api sso token updated")
 }
 
-// doRequest performs a GET request, handling redirect errors as expired tokens.
-func (c *client) doRequest(ctx context.Context, endpoint string) (*resty.Response, error) {
+// AddToken adds a named SSO token to the pool, or replaces and reactivates an existing one.
+func (c *client) AddToken(name, token string) {
+	c.tokens.put(name, token)
+	slog.Info("cod api sso token added to pool", "token", name)
+}
+
+// RemoveToken removes a named SSO token from the pool.
+func (c *client) RemoveToken(name string) bool {
+	removed := c.tokens.remove(name)
+	if removed {
+		slog.Info("cod api sso token removed from pool", "token", name)
+	}
+	return removed
+}
+
+// ListTokens describes every pooled SSO token.
+func (c *client) ListTokens() []TokenInfo {
+	return c.tokens.list()
+}
+
+// CircuitStates describes the circuit breaker of every endpoint family.
+func (c *client) CircuitStates() []CircuitInfo {
+	return c.breakers.list()
+}
+
+// LimiterStats describes the outbound request budget and its queues.
+func (c *client) LimiterStats() LimiterStats {
+	return c.limiter.stats()
+}
+
+// Outage retries made by doRequest, backing off exponentially from the client's
+// retryWait (retryWaitTime by default) up to retryMaxWaitTime.
+const (
+	maxOutageRetries = 3
+	retryWaitTime    = 1 * time.Second
+	retryMaxWaitTime = 5 * time.Second
+)
+
+// doRequest performs a GET request with the next pooled token. If that token turns
+// out to be rate limited or expired, it is benched or retired and the request fails
+// over to the next token; an outage (5xx or network error) is retried after a backoff.
+// Every attempt waits on the rate limiter and goes through the family's circuit breaker,
+// which fails requests fast with ErrAPIUnavailable while the upstream is down.
+func (c *client) doRequest(ctx context.Context, family, endpoint string) (*resty.Response, error) {
+	b := c.breakers[family]
+	tokenAttempts := max(1, c.tokens.size())
+	retries := 0
+	var lastErr error
+	for tokenAttempts > 0 {
+		token, err := c.tokens.acquire()
+		if err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		if err := b.allow(); err != nil {
+			slog.Debug("cod api circuit open, failing fast", "circuit", family)
+			return nil, err
+		}
+		resp, retryAfter, err := c.doRequestWithToken(ctx, endpoint, token.value)
+		if errors.Is(err, ErrAPIUnavailable) && ctx.Err() != nil {
+			// The caller gave up; that says nothing about upstream health
+			b.abandon()
+			return nil, err
+		}
+		b.record(err)
+		if err == nil {
+			return resp, nil
+		}
+
+		switch {
+		case errors.Is(err, ErrRateLimited), errors.Is(err, ErrTokenExpired):
+			c.tokens.report(token, err, retryAfter)
+			tokenAttempts--
+		case errors.Is(err, ErrAPIUnavailable) && retries < maxOutageRetries:
+			wait := min(c.retryWait<<retries, retryMaxWaitTime)
+			retries++
+			slog.Debug("cod api unavailable, retrying", "endpoint", endpoint, "attempt", retries, "wait", wait)
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(wait):
+			}
+		default:
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// doRequestWithToken performs a GET request, handling redirect errors as expired tokens.
+// On a 429 it also returns how long the upstream asked callers to back off, if it said.
+func (c *client) doRequestWithToken(ctx context.Context, endpoint, token string) (*resty.Response, time.Duration, error) {
 	resp, err := c.http.R().
 		SetContext(ctx).
-		SetCookie(c.authCookie()).
+		SetCookie(&http.Cookie{Name: "ACT_SSO_COOKIE", Value: token}).
 		Get(endpoint)
 	if err != nil {
 		// resty returns an error on redirect when NoRedirectPolicy is set,
@@ -146,16 +365,24 @@ func (c *client) doRequest(ctx context.Context, endpoint string) (*resty.Respons
 			slog.Warn("cod api redirected, token likely expired",
 				"status", resp.StatusCode(),
 				"location", resp.Header().Get("Location"))
-			return nil, ErrTokenExpired
+			return nil, 0, ErrTokenExpired
 		}
 		slog.Error("cod api request failed", "endpoint", endpoint, "error", err)
-		return nil, ErrAPIUnavailable
+		return nil, 0, ErrAPIUnavailable
 	}
 
 	if err := c.checkResponse(resp); err != nil {
-		return nil, err
+		var wait time.Duration
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			if d := retryAfter(resp.Header()); d > 0 {
+				slog.Warn("cod api asked to back off", "retry_after", d)
+				c.limiter.pause(d)
+				wait = d
+			}
+		}
+		return nil, wait, err
 	}
-	return resp, nil
+	return resp, 0, nil
 }
 
 func (c *client) checkResponse(resp *resty.Response) error {
@@ -222,7 +449,7 @@ func (c *client) mapAPIError(msg string) error {
 	}
 }
 
-func (c *client) mapProfileToStats(resp profileResponse, platform, gamertag string) *PlayerStats {
+func mapProfileToStats(resp profileResponse, f StatFields, platform, gamertag string) *PlayerStats {
 	stats := &PlayerStats{
 		Platform: platform,
 		Gamertag: gamertag,
@@ -231,31 +458,34 @@ func (c *client) mapProfileToStats(resp profileResponse, platform, gamertag stri
 	}
 
 	if props, ok := resp.Data.Lifetime.All["properties"]; ok {
-		stats.Kills = int(props.Kills)
-		stats.Deaths = int(props.Deaths)
-		stats.KDRatio = props.KDRatio
-		stats.Wins = int(props.Wins)
-		stats.Losses = int(props.Losses)
-		stats.WinPct = props.WinPct
-		stats.ScorePerMin = props.ScorePerMin
-		stats.Headshots = int(props.Headshots)
-		stats.TimePlayed = int(props.TimePlayed)
-		stats.MatchesPlayed = int(props.MatchesPlayed)
-		stats.TopFive = int(props.TopFive)
-		stats.TopTen = int(props.TopTen)
-		stats.TopTwentyFive = int(props.TopTwentyFive)
-		stats.Assists = int(props.Assists)
-		stats.DamageDone = int(props.DamageDone)
+		stats.Kills = toInt(props[f.Kills])
+		stats.Deaths = toInt(props[f.Deaths])
+		stats.KDRatio = toFloat(props[f.KDRatio])
+		stats.Wins = toInt(props[f.Wins])
+		stats.Losses = toInt(props[f.Losses])
+		stats.WinPct = toFloat(props[f.WinPct])
+		stats.ScorePerMin = toFloat(props[f.ScorePerMin])
+		stats.Headshots = toInt(props[f.Headshots])
+		stats.TimePlayed = toInt(props[f.TimePlayed])
+		stats.MatchesPlayed = toInt(props[f.MatchesPlayed])
+		stats.TopFive = toInt(props[f.TopFive])
+		stats.TopTen = toInt(props[f.TopTen])
+		stats.TopTwentyFive = toInt(props[f.TopTwentyFive])
+		stats.Assists = toInt(props[f.Assists])
+		stats.DamageDone = toInt(props[f.DamageDone])
 	}
 
 	// Parse per-mode breakdown from resp.Data.Lifetime.Mode
-	stats.ModeBreakdown = c.parseModeBreakdown(resp.Data.Lifetime.Mode)
+	stats.ModeBreakdown = parseModeBreakdown(resp.Data.Lifetime.Mode, f)
+
+	// Parse per-weapon and per-class stats from resp.Data.Lifetime.ItemData
+	stats.Weapons, stats.WeaponClasses = parseWeaponStats(resp.Data.Lifetime.ItemData)
 
 	return stats
 }
 
 // parseModeBreakdown extracts per-mode stats from the API's Mode map.
-func (c *client) parseModeBreakdown(modeData map[string]any) map[string]ModeStats {
+func parseModeBreakdown(modeData map[string]any, f StatFields) map[string]ModeStats {
 	if len(modeData) == 0 {
 		return nil
 	}
@@ -276,17 +506,17 @@ func (c *client) parseModeBreakdown(modeData map[string]any) map[string]ModeStat
 		}
 
 		breakdown[modeName] = ModeStats{
-			Kills:         toInt(props["kills"]),
-			Deaths:        toInt(props["deaths"]),
-			KDRatio:       toFloat(props["kdRatio"]),
-			Wins:          toInt(props["wins"]),
-			Losses:        toInt(props["losses"]),
-			MatchesPlayed: toInt(props["matchesPlayed"]),
-			ScorePerMin:   toFloat(props["scorePerMinute"]),
-			TimePlayed:    toInt(props["timePlayed"]),
-			TopFive:       toInt(props["topFive"]),
-			TopTen:        toInt(props["topTen"]),
-			TopTwentyFive: toInt(props["topTwentyFive"]),
+			Kills:         toInt(props[f.Kills]),
+			Deaths:        toInt(props[f.Deaths]),
+			KDRatio:       toFloat(props[f.KDRatio]),
+			Wins:          toInt(props[f.Wins]),
+			Losses:        toInt(props[f.Losses]),
+			MatchesPlayed: toInt(props[f.MatchesPlayed]),
+			ScorePerMin:   toFloat(props[f.ScorePerMin]),
+			TimePlayed:    toInt(props[f.TimePlayed]),
+			TopFive:       toInt(props[f.TopFive]),
+			TopTen:        toInt(props[f.TopTen]),
+			TopTwentyFive: toInt(props[f.TopTwentyFive]),
 		}
 	}
 
@@ -296,6 +526,82 @@ func (c *client) parseModeBreakdown(modeData map[string]any) map[string]ModeStat
 	return breakdown
 }
 
+// parseWeaponStats extracts per-weapon stats from the API's itemData map, which is keyed
+// by weapon class (e.g. "weapon_assault_rifle") and then by weapon name. Class totals
+// are summed from the weapons in each class.
+func parseWeaponStats(itemData map[string]any) (map[string]WeaponStats, map[string]WeaponStats) {
+	if len(itemData) == 0 {
+		return nil, nil
+	}
+
+	weapons := make(map[string]WeaponStats)
+	classes := make(map[string]WeaponStats)
+	for className, classVal := range itemData {
+		classMap, ok := classVal.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		total := WeaponStats{Class: className}
+		parsed := 0
+		for weaponName, weaponVal := range classMap {
+			weaponMap, ok := weaponVal.(map[string]any)
+			if !ok {
+				continue
+			}
+			propsVal, ok := weaponMap["properties"]
+			if !ok {
+				continue
+			}
+			props, ok := propsVal.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			w := WeaponStats{
+				Name:      weaponName,
+				Class:     className,
+				Kills:     toInt(props["kills"]),
+				Deaths:    toInt(props["deaths"]),
+				KDRatio:   toFloat(props["kdRatio"]),
+				Headshots: toInt(props["headshots"]),
+				Hits:      toInt(props["hits"]),
+				Shots:     toInt(props["shots"]),
+				Accuracy:  toFloat(props["accuracy"]),
+			}
+			if w.Accuracy == 0 && w.Shots > 0 {
+				w.Accuracy = float64(w.Hits) / float64(w.Shots)
+			}
+			weapons[weaponName] = w
+			parsed++
+
+			total.Kills += w.Kills
+			total.Deaths += w.Deaths
+			total.Headshots += w.Headshots
+			total.Hits += w.Hits
+			total.Shots += w.Shots
+		}
+		if parsed == 0 {
+			continue
+		}
+
+		if total.Deaths > 0 {
+			total.KDRatio = float64(total.Kills) / float64(total.Deaths)
+		} else {
+			total.KDRatio = float64(total.Kills)
+		}
+		if total.Shots > 0 {
+			total.Accuracy = float64(total.Hits) / float64(total.Shots)
+		}
+		classes[className] = total
+	}
+
+	if len(weapons) == 0 {
+		return nil, nil
+	}
+	return weapons, classes
+}
+
 func toFloat(v any) float64 {
 	switch n := v.(type) {
 	case float64:
@@ -0,0 +1,210 @@
+package codclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient/fake"
+)
+
+const (
+	testGamertag = "Demo#1234"
+	testMatchID  = "13590000000000000000"
+)
+
+// newTestClient starts a fake CoD API and returns a client pointed at it. Outage retries
+// back off by a millisecond so failure tests stay fast.
+func newTestClient(t *testing.T) (*client, *fake.Server) {
+	t.Helper()
+	srv := fake.New(fake.DefaultFixtures())
+	ts := srv.Start()
+	t.Cleanup(ts.Close)
+
+	c := New(ts.URL, "test-token", RateLimit{PerMinute: 6000, Burst: 100}, 0).(*client)
+	c.retryWait = time.Millisecond
+	return c, srv
+}
+
+func TestClientMapsFaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault fake.Fault
+		want  error
+	}{
+		{"login redirect", fake.Fault{Kind: fake.LoginRedirect}, ErrTokenExpired},
+		{"html login page", fake.Fault{Kind: fake.HTMLLogin}, ErrTokenExpired},
+		{"user not found envelope", fake.Fault{Kind: fake.ErrorEnvelope, Message: "Not permitted: user not found"}, ErrPlayerNotFound},
+		{"rate limited", fake.Fault{Kind: fake.RateLimited}, ErrRateLimited},
+		{"server error", fake.Fault{Kind: fake.ServerError}, ErrAPIUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t)
+			srv.Inject(testGamertag, tt.fault)
+
+			_, err := c.GetPlayerStats(context.Background(), "uno", testGamertag, "mw", "wz")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetPlayerStats error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientHonoursRetryAfter(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.Inject(testGamertag, fake.Fault{Kind: fake.RateLimited, RetryAfter: 30 * time.Second, Times: 1})
+
+	before := time.Now()
+	_, err := c.GetPlayerStats(context.Background(), "uno", testGamertag, "mw", "wz")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetPlayerStats error = %v, want %v", err, ErrRateLimited)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("upstream requests = %d, want 1; a 429 must not be retried", n)
+	}
+
+	paused := c.LimiterStats().PausedUntil
+	if paused == nil || paused.Before(before.Add(29*time.Second)) {
+		t.Fatalf("limiter paused until %v, want about 30s after %v", paused, before)
+	}
+	tokens := c.ListTokens()
+	if len(tokens) != 1 || tokens[0].BenchedUntil == nil {
+		t.Fatalf("tokens = %+v, want the token benched", tokens)
+	}
+	if benched := *tokens[0].BenchedUntil; benched.Before(before.Add(29*time.Second)) || benched.After(time.Now().Add(31*time.Second)) {
+		t.Errorf("token benched until %v, want about 30s after %v rather than the cooldown", benched, before)
+	}
+}
+
+func TestClientRetriesOutages(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.Inject(testGamertag, fake.Fault{Kind: fake.ServerError, Times: 2})
+
+	stats, err := c.GetPlayerStats(context.Background(), "uno", testGamertag, "mw", "wz")
+	if err != nil {
+		t.Fatalf("GetPlayerStats: %v", err)
+	}
+	if stats.Gamertag != testGamertag {
+		t.Errorf("Gamertag = %q, want %q", stats.Gamertag, testGamertag)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("upstream requests = %d, want 3", n)
+	}
+}
+
+func TestClientGivesUpOnPersistentOutage(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.Inject(testGamertag, fake.Fault{Kind: fake.ServerError, Status: 502})
+
+	_, err := c.GetPlayerStats(context.Background(), "uno", testGamertag, "mw", "wz")
+	if !errors.Is(err, ErrAPIUnavailable) {
+		t.Fatalf("GetPlayerStats error = %v, want %v", err, ErrAPIUnavailable)
+	}
+	if n := len(srv.Requests()); n != 1+maxOutageRetries {
+		t.Errorf("upstream requests = %d, want %d", n, 1+maxOutageRetries)
+	}
+}
+
+func TestParseProfileFixture(t *testing.T) {
+	raw, err := fs.ReadFile(fake.DefaultFixtures(), fake.FixtureName("profile", "mw", "uno", testGamertag, "wz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := ParseProfile(raw, "mw", "uno", testGamertag)
+	if err != nil {
+		t.Fatalf("ParseProfile: %v", err)
+	}
+	if stats.Kills != 8421 || stats.Level != 155 {
+		t.Errorf("Kills, Level = %d, %d, want 8421, 155", stats.Kills, stats.Level)
+	}
+	if stats.Kills > 0 && stats.Deaths == 0 {
+		t.Error("Deaths not parsed")
+	}
+	for _, mode := range []string{"br", "br_dmz", "resurgence"} {
+		if _, ok := stats.ModeBreakdown[mode]; !ok {
+			t.Errorf("ModeBreakdown missing %q", mode)
+		}
+	}
+	if len(stats.RawData) == 0 {
+		t.Error("RawData not kept")
+	}
+}
+
+func TestMapMatchFixture(t *testing.T) {
+	raw, err := fs.ReadFile(fake.DefaultFixtures(), fake.FixtureName("matches", "mw", "uno", testGamertag, "wz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp matchesResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data.Matches) == 0 {
+		t.Fatal("fixture has no matches")
+	}
+
+	m := mapMatch(resp.Data.Matches[0])
+	if m.MatchID != testMatchID || m.Mode != "br_brquads" {
+		t.Errorf("MatchID, Mode = %q, %q, want %q, br_brquads", m.MatchID, m.Mode, testMatchID)
+	}
+	if m.Kills != 12 || m.Headshots != 4 {
+		t.Errorf("Kills, Headshots = %d, %d, want 12, 4", m.Kills, m.Headshots)
+	}
+	if m.MatchTime.IsZero() {
+		t.Error("MatchTime not parsed")
+	}
+	if len(m.RawData) == 0 {
+		t.Error("RawData not kept")
+	}
+}
+
+func TestGetRecentMatchesFromFakeServer(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	matches, err := c.GetRecentMatches(context.Background(), "uno", testGamertag, "mw", "wz")
+	if err != nil {
+		t.Fatalf("GetRecentMatches: %v", err)
+	}
+	if len(matches) != 20 {
+		t.Errorf("got %d matches, want one page of 20", len(matches))
+	}
+	if matches[0].MatchID != testMatchID {
+		t.Errorf("first MatchID = %q, want %q", matches[0].MatchID, testMatchID)
+	}
+}
+
+func TestGetMatchDetailsFromFakeServer(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	details, err := c.GetMatchDetails(context.Background(), "mw", "uno", testMatchID)
+	if err != nil {
+		t.Fatalf("GetMatchDetails: %v", err)
+	}
+	if len(details.Participants) == 0 {
+		t.Error("no participants parsed from the fullMatch fixture")
+	}
+	if reqs := srv.Requests(); len(reqs) != 1 {
+		t.Fatalf("upstream requests = %v, want 1", reqs)
+	}
+}
+
+func TestGetMatchDetailsUsesTitleMode(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	// mw2 matches live under fullMatch/wz2; the fake answers unknown matches with an empty lobby
+	_, err := c.GetMatchDetails(context.Background(), "mw2", "uno", testMatchID)
+	if !errors.Is(err, ErrMatchNotFound) {
+		t.Fatalf("GetMatchDetails error = %v, want %v (requests %v)", err, ErrMatchNotFound, srv.Requests())
+	}
+	reqs := srv.Requests()
+	if !slices.ContainsFunc(reqs, func(path string) bool { return strings.Contains(path, "/fullMatch/wz2/") }) {
+		t.Errorf("upstream requests = %v, want one under /fullMatch/wz2/", reqs)
+	}
+}
@@ -0,0 +1,500 @@
+// Package demo provides a synthetic CodClient for running the server without CoD
+// credentials. Every gamertag gets a stable, plausible profile and match history per
+// title and mode, derived from a hash of its name, so the same player looks the same
+// on every run.
+package demo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+const (
+	// slotLength is the grid matches are scheduled on; a player plays in some slots.
+	slotLength = 40 * time.Minute
+
+	// historyDays bounds how far back a synthetic match history reaches.
+	historyDays = 365
+
+	// pageSize mirrors the number of matches the real endpoint returns per page.
+	pageSize = 20
+
+	lobbyTeams = 38
+	teamSize   = 4
+)
+
+var (
+	maps  = []string{"mp_don3", "mp_kstenod", "mp_escape4", "mp_wz_island"}
+	modes = []string{"br_brquads", "br_brtrios", "br_brduos", "br_rebirth_rbrthquad", "br_dmz_plunquad"}
+
+	// modeShares splits lifetime totals across the per-mode breakdown.
+	modeShares = []struct {
+		name  string
+		share float64
+	}{
+		{"br", 0.62},
+		{"resurgence", 0.26},
+		{"br_dmz", 0.12},
+	}
+
+	weaponPool = []struct{ name, class string }{
+		{"iw8_ar_m4", "weapon_assault_rifle"},
+		{"iw8_ar_kilo433", "weapon_assault_rifle"},
+		{"iw8_ar_mcharlie", "weapon_assault_rifle"},
+		{"iw8_ar_asierra12", "weapon_assault_rifle"},
+		{"iw8_sm_mp5", "weapon_smg"},
+		{"iw8_sm_mpapa7", "weapon_smg"},
+		{"iw8_sm_uzulu", "weapon_smg"},
+		{"iw8_sn_hdr", "weapon_sniper"},
+		{"iw8_sn_kilo98", "weapon_sniper"},
+		{"iw8_lm_kilo121", "weapon_lmg"},
+		{"iw8_sh_romeo870", "weapon_shotgun"},
+		{"iw8_pi_golf21", "weapon_pistol"},
+	}
+
+	lobbyNames = []string{
+		"Ghost", "Viper", "Reaper", "Nova", "Havoc", "Blaze", "Onyx", "Rogue", "Talon", "Wraith",
+		"Echo", "Frost", "Jolt", "Kestrel", "Lynx", "Mako", "Nomad", "Raven", "Saber", "Titan",
+	}
+)
+
+// client is a CodClient that fabricates all of its data.
+type client struct{}
+
+// New creates a synthetic CodClient. Gamertags containing "private" or "notfound"
+// simulate private profiles and missing players.
+func New() codclient.CodClient {
+	return &client{}
+}
+
+func (c *client) GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	if err := lookupError(gamertag); err != nil {
+		return nil, err
+	}
+
+	p := newProfile(gamertag, t.ID, mode)
+	r := p.rand("stats")
+
+	matches := p.matchesPlayed
+	kills := int(float64(matches) * p.killsPerMatch)
+	deaths := int(float64(kills) / p.kd)
+	wins := int(float64(matches) * p.winRate)
+	topFive := wins + int(float64(matches)*p.winRate*2.5)
+	topTen := topFive + int(float64(matches)*p.winRate*2)
+	topTwentyFive := topTen + int(float64(matches)*0.15)
+	timePlayed := matches * (1100 + r.IntN(400))
+
+	stats := &codclient.PlayerStats{
+		Platform:      platform,
+		Gamertag:      gamertag,
+		Level:         1 + r.IntN(155),
+		Prestige:      r.IntN(12),
+		Kills:         kills,
+		Deaths:        deaths,
+		KDRatio:       round(float64(kills)/float64(max(1, deaths)), 4),
+		Wins:          wins,
+		Losses:        matches - wins,
+		WinPct:        round(float64(wins)/float64(max(1, matches)), 4),
+		ScorePerMin:   round(180+p.kd*70+r.Float64()*40, 2),
+		Headshots:     int(float64(kills) * (0.14 + r.Float64()*0.1)),
+		TimePlayed:    timePlayed,
+		MatchesPlayed: matches,
+		TopFive:       topFive,
+		TopTen:        topTen,
+		TopTwentyFive: topTwentyFive,
+		Assists:       int(float64(kills) * (0.3 + r.Float64()*0.2)),
+		DamageDone:    kills*240 + r.IntN(max(1, kills*40)),
+		ModeBreakdown: make(map[string]codclient.ModeStats, len(modeShares)),
+	}
+
+	for _, ms := range modeShares {
+		mr := p.rand("mode:" + ms.name)
+		mMatches := int(float64(matches) * ms.share)
+		mKD := p.kd * (0.85 + mr.Float64()*0.3)
+		mKills := int(float64(mMatches) * p.killsPerMatch * mKD / p.kd)
+		mWins := int(float64(mMatches) * p.winRate * (0.7 + mr.Float64()*0.6))
+		stats.ModeBreakdown[ms.name] = codclient.ModeStats{
+			Kills:         mKills,
+			Deaths:        int(float64(mKills) / mKD),
+			KDRatio:       round(mKD, 4),
+			Wins:          mWins,
+			Losses:        mMatches - mWins,
+			MatchesPlayed: mMatches,
+			ScorePerMin:   round(stats.ScorePerMin*(0.9+mr.Float64()*0.2), 2),
+			TimePlayed:    int(float64(timePlayed) * ms.share),
+			TopFive:       mWins * 3,
+			TopTen:        mWins * 5,
+			TopTwentyFive: mWins*5 + mMatches/7,
+		}
+	}
+
+	stats.Weapons, stats.WeaponClasses = p.weapons(kills, deaths)
+	return stats, nil
+}
+
+func (c *client) GetRecentMatches(ctx context.Context, platform, gamertag, title, mode string) ([]codclient.Match, error) {
+	return c.GetMatchesRange(ctx, platform, gamertag, title, mode, time.Time{}, time.Time{})
+}
+
+// GetMatchesRange walks the player's schedule backwards from end (or now), returning up
+// to one page of matches that started within the window.
+func (c *client) GetMatchesRange(ctx context.Context, platform, gamertag, title, mode string, start, end time.Time) ([]codclient.Match, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	if err := lookupError(gamertag); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+	floor := now.AddDate(0, 0, -historyDays)
+	if start.Before(floor) {
+		start = floor
+	}
+
+	p := newProfile(gamertag, t.ID, mode)
+	matches := make([]codclient.Match, 0, pageSize)
+	for slot := slotOf(end); len(matches) < pageSize; slot-- {
+		at := slotStart(slot)
+		if at.Before(start) {
+			break
+		}
+		if at.After(end) || !p.playsIn(slot) {
+			continue
+		}
+		matches = append(matches, p.match(slot))
+	}
+	return matches, nil
+}
+
+// GetMatchDetails fabricates a full lobby. The lobby is seeded by the title and match ID,
+// and the player whose history the match came from, when the ID names them, is placed on
+// their own team with the result their match list reports.
+func (c *client) GetMatchDetails(ctx context.Context, title, platform, matchID string) (*codclient.MatchDetails, error) {
+	t, _, err := codclient.ResolveTitle(title, "")
+	if err != nil {
+		return nil, err
+	}
+	mode, slot, gamertag, ok := parseMatchID(matchID)
+	if !ok || !t.SupportsMode(mode) {
+		return nil, codclient.ErrMatchNotFound
+	}
+	var own codclient.Match
+	if gamertag != "" {
+		own = newProfile(gamertag, t.ID, mode).match(slot)
+	}
+
+	r := seeded("lobby:" + t.ID + ":" + matchID)
+	details := &codclient.MatchDetails{
+		MatchID:      matchID,
+		Mode:         modes[r.IntN(len(modes))],
+		Map:          maps[r.IntN(len(maps))],
+		Duration:     (20 + r.IntN(12)) * 60 * 1000,
+		MatchTime:    slotStart(slot),
+		Participants: make([]codclient.MatchParticipant, 0, lobbyTeams*teamSize),
+	}
+
+	placements := r.Perm(lobbyTeams)
+	for team := range lobbyTeams {
+		placement := placements[team] + 1
+		for i := range teamSize {
+			if i == 0 && placement == own.Placement {
+				details.Participants = append(details.Participants, codclient.MatchParticipant{
+					Username:    gamertag,
+					Team:        fmt.Sprintf("team_%d", team+1),
+					Placement:   placement,
+					Kills:       own.Kills,
+					Deaths:      own.Deaths,
+					KDRatio:     own.KDRatio,
+					DamageDealt: own.DamageDealt,
+					DamageTaken: own.DamageTaken,
+				})
+				continue
+			}
+			kills := r.IntN(max(2, 16-placement/3))
+			deaths := 1 + r.IntN(4)
+			if placement == 1 {
+				deaths = r.IntN(3)
+			}
+			details.Participants = append(details.Participants, codclient.MatchParticipant{
+				Username:    fmt.Sprintf("%s%d", lobbyNames[r.IntN(len(lobbyNames))], r.IntN(10000)),
+				UnoID:       strconv.FormatUint(r.Uint64()>>1, 10),
+				Team:        fmt.Sprintf("team_%d", team+1),
+				Placement:   placement,
+				Kills:       kills,
+				Deaths:      deaths,
+				KDRatio:     round(float64(kills)/float64(max(1, deaths)), 3),
+				DamageDealt: kills*230 + r.IntN(900),
+				DamageTaken: deaths*280 + r.IntN(600),
+			})
+		}
+	}
+	return details, nil
+}
+
+// UpdateToken is a no-op; demo mode never authenticates.
+func (c *client) UpdateToken(newToken string) {
+	slog.Debug("demo mode ignoring sso token update")
+}
+
+// AddToken is a no-op; demo mode never authenticates.
+func (c *client) AddToken(name, token string) {}
+
+// RemoveToken reports that no token was removed.
+func (c *client) RemoveToken(name string) bool { return false }
+
+// ListTokens returns an empty pool.
+func (c *client) ListTokens() []codclient.TokenInfo { return []codclient.TokenInfo{} }
+
+// CircuitStates returns no circuits; nothing upstream can fail.
+func (c *client) CircuitStates() []codclient.CircuitInfo { return nil }
+
+// LimiterStats reports a disabled limiter.
+func (c *client) LimiterStats() codclient.LimiterStats {
+	return codclient.LimiterStats{Queued: map[string]int{}}
+}
+
+// lookupError simulates private and missing profiles by gamertag.
+func lookupError(gamertag string) error {
+	lower := strings.ToLower(gamertag)
+	switch {
+	case strings.Contains(lower, "private"):
+		return codclient.ErrPrivateProfile
+	case strings.Contains(lower, "notfound"):
+		return codclient.ErrPlayerNotFound
+	default:
+		return nil
+	}
+}
+
+// profile holds the traits that shape a player's synthetic numbers in one title and mode.
+type profile struct {
+	gamertag      string
+	title         string
+	mode          string
+	key           string
+	kd            float64
+	killsPerMatch float64
+	winRate       float64
+	matchesPlayed int
+	playRate      float64 // fraction of schedule slots the player appears in
+}
+
+func newProfile(gamertag, title, mode string) profile {
+	key := strings.ToLower(gamertag) + ":" + title + ":" + mode
+	r := seeded("profile:" + key)
+
+	// Skill is roughly log-normal: most players sit near 1.0 K/D with a long tail
+	kd := math.Exp(r.NormFloat64()*0.35) * 0.95
+	kd = math.Min(math.Max(kd, 0.35), 4.5)
+
+	return profile{
+		gamertag:      gamertag,
+		title:         title,
+		mode:          mode,
+		key:           key,
+		kd:            kd,
+		killsPerMatch: 1.2 + kd*1.8 + r.Float64(),
+		winRate:       math.Min(0.02+kd*0.025+r.Float64()*0.02, 0.25),
+		matchesPlayed: 150 + r.IntN(3500),
+		playRate:      0.15 + r.Float64()*0.35,
+	}
+}
+
+func (p profile) rand(salt string) *rand.Rand {
+	return seeded(p.key + ":" + salt)
+}
+
+func (p profile) playsIn(slot int64) bool {
+	return p.rand("slot:"+strconv.FormatInt(slot, 10)).Float64() < p.playRate
+}
+
+func (p profile) match(slot int64) codclient.Match {
+	id := matchID(p.mode, slot, p.gamertag)
+	lobby := seeded("lobby:" + p.title + ":" + id)
+	mode := modes[lobby.IntN(len(modes))]
+	mapName := maps[lobby.IntN(len(maps))]
+	duration := (20 + lobby.IntN(12)) * 60 * 1000
+
+	r := p.rand("match:" + id)
+	kills := int(math.Round(math.Max(0, p.killsPerMatch*(0.2+r.ExpFloat64()*0.8))))
+	deaths := 1 + r.IntN(4)
+	placement := 1 + int(math.Min(lobbyTeams-1, r.ExpFloat64()*float64(lobbyTeams)/(2+p.kd*2)))
+	if placement == 1 {
+		deaths = r.IntN(3)
+	}
+	gulag := ""
+	switch r.IntN(3) {
+	case 0:
+		gulag = "win"
+	case 1:
+		gulag = "loss"
+	}
+
+	damageDealt := kills*230 + r.IntN(1200)
+	damageTaken := deaths*280 + r.IntN(700)
+
+	// Better placements survive longer, so they move further and loot more
+	survived := 1 - float64(placement-1)/lobbyTeams*0.85
+	timePlayed := int(float64(duration/1000) * survived)
+
+	return codclient.Match{
+		MatchID:     id,
+		Mode:        mode,
+		Map:         mapName,
+		Placement:   placement,
+		Kills:       kills,
+		Deaths:      deaths,
+		KDRatio:     round(float64(kills)/float64(max(1, deaths)), 3),
+		DamageDealt: damageDealt,
+		DamageTaken: damageTaken,
+		GulagResult: gulag,
+		Duration:    duration,
+		MatchTime:   slotStart(slot),
+
+		Headshots:         int(float64(kills) * (0.1 + r.Float64()*0.25)),
+		Assists:           r.IntN(kills/2 + 2),
+		Score:             kills*100 + damageDealt/2 + r.IntN(1500),
+		TimePlayed:        timePlayed,
+		DistanceTraveled:  round(float64(timePlayed)*(150+r.Float64()*150), 1),
+		PercentTimeMoving: round(65+r.Float64()*30, 1),
+		Revives:           r.IntN(3),
+		TeamWipes:         kills / (3 + r.IntN(3)),
+		CachesOpened:      int(survived*12) + r.IntN(6),
+		Contracts:         int(survived*3) + r.IntN(2),
+		Cash:              (int(survived*20000) + r.IntN(8000)) / 100 * 100,
+	}
+}
+
+// weapons spreads lifetime kills and deaths over a handful of the player's favourite
+// weapons, summing per-class totals the way the real client does.
+func (p profile) weapons(kills, deaths int) (map[string]codclient.WeaponStats, map[string]codclient.WeaponStats) {
+	r := p.rand("weapons")
+	picks := r.Perm(len(weaponPool))[:6]
+
+	weights := make([]float64, len(picks))
+	total := 0.0
+	for i := range picks {
+		weights[i] = r.ExpFloat64() + 0.2
+		total += weights[i]
+	}
+
+	weapons := make(map[string]codclient.WeaponStats, len(picks))
+	classes := make(map[string]codclient.WeaponStats)
+	for i, idx := range picks {
+		wp := weaponPool[idx]
+		share := weights[i] / total
+		wKills := int(float64(kills) * share)
+		wDeaths := int(float64(deaths) * share * (0.75 + r.Float64()*0.5))
+		shots := wKills*(35+r.IntN(30)) + r.IntN(500)
+		hits := int(float64(shots) * (0.17 + r.Float64()*0.12))
+		w := codclient.WeaponStats{
+			Name:      wp.name,
+			Class:     wp.class,
+			Kills:     wKills,
+			Deaths:    wDeaths,
+			KDRatio:   round(float64(wKills)/float64(max(1, wDeaths)), 4),
+			Headshots: int(float64(wKills) * (0.12 + r.Float64()*0.15)),
+			Hits:      hits,
+			Shots:     shots,
+			Accuracy:  round(float64(hits)/float64(max(1, shots)), 4),
+		}
+		weapons[wp.name] = w
+
+		c := classes[wp.class]
+		c.Class = wp.class
+		c.Kills += w.Kills
+		c.Deaths += w.Deaths
+		c.Headshots += w.Headshots
+		c.Hits += w.Hits
+		c.Shots += w.Shots
+		classes[wp.class] = c
+	}
+	for name, c := range classes {
+		c.KDRatio = round(float64(c.Kills)/float64(max(1, c.Deaths)), 4)
+		c.Accuracy = round(float64(c.Hits)/float64(max(1, c.Shots)), 4)
+		classes[name] = c
+	}
+	return weapons, classes
+}
+
+func slotOf(t time.Time) int64 {
+	return t.Unix() / int64(slotLength/time.Second)
+}
+
+func slotStart(slot int64) time.Time {
+	return time.Unix(slot*int64(slotLength/time.Second), 0)
+}
+
+// maxMatchIDLen is the longest match ID the API handlers accept and matches.match_id stores.
+const maxMatchIDLen = 100
+
+// matchID encodes the mode, schedule slot and player so GetMatchDetails can rebuild the
+// player's own result and recover the match time. The gamertag is base64url-encoded to
+// keep the ID safe in a URL path; one too long to fit is hashed instead, and its lobby
+// goes without the player.
+func matchID(mode string, slot int64, gamertag string) string {
+	id := fmt.Sprintf("demo-%s-%d-%s", mode, slot, base64.RawURLEncoding.EncodeToString([]byte(gamertag)))
+	if len(id) <= maxMatchIDLen {
+		return id
+	}
+	h := fnv.New64a()
+	h.Write([]byte(gamertag))
+	return fmt.Sprintf("demoh-%s-%d-%016x", mode, slot, h.Sum64())
+}
+
+// parseMatchID reverses matchID. The gamertag is empty for an ID that hashed it.
+func parseMatchID(id string) (mode string, slot int64, gamertag string, ok bool) {
+	rest, hashed := strings.CutPrefix(id, "demoh-")
+	if !hashed {
+		if rest, ok = strings.CutPrefix(id, "demo-"); !ok {
+			return "", 0, "", false
+		}
+	}
+	parts := strings.SplitN(rest, "-", 3)
+	if len(parts) != 3 {
+		return "", 0, "", false
+	}
+	slot, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, "", false
+	}
+	if hashed {
+		return parts[0], slot, "", true
+	}
+	tag, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(tag) == 0 {
+		return "", 0, "", false
+	}
+	return parts[0], slot, string(tag), true
+}
+
+func seeded(s string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	sum := h.Sum64()
+	return rand.New(rand.NewPCG(sum, sum^0x9e3779b97f4a7c15))
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -1,6 +1,9 @@
 package codclient
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrPrivateProfile = errors.New("player profile is set to private")
@@ -8,4 +11,25 @@ var (
 	ErrRateLimited    = errors.New("rate limited by CoD API")
 	ErrAPIUnavailable = errors.New("CoD API is unavailable")
 	ErrTokenExpired   = errors.New("SSO token has expired")
+	ErrMatchNotFound  = errors.New("match not found")
 )
+
+// ErrUnsupportedTitle matches every UnsupportedTitleError.
+var ErrUnsupportedTitle = errors.New("unsupported title or mode")
+
+// UnsupportedTitleError reports a title, or a title/mode combination, missing from the registry.
+type UnsupportedTitleError struct {
+	Title string
+	Mode  string
+}
+
+func (e *UnsupportedTitleError) Error() string {
+	if _, ok := LookupTitle(e.Title); ok {
+		return fmt.Sprintf("mode %q is not supported for title %q", e.Mode, e.Title)
+	}
+	return fmt.Sprintf("title %q is not supported", e.Title)
+}
+
+func (e *UnsupportedTitleError) Is(target error) bool {
+	return target == ErrUnsupportedTitle
+}
@@ -0,0 +1,290 @@
+// Package fake provides a stand-in for the CoD API that serves the profile, matches and
+// fullMatch endpoints from JSON fixtures. It implements http.Handler, so it can run under
+// httptest.NewServer in tests or as a standalone server for offline development, and can
+// inject the failure modes the real API is known for.
+package fake
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures
+var embedded embed.FS
+
+// DefaultFixtures returns the fixtures bundled with the package.
+func DefaultFixtures() fs.FS {
+	sub, err := fs.Sub(embedded, "fixtures")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// matchesPageSize mirrors the number of matches the real endpoint returns per page.
+const matchesPageSize = 20
+
+// loginURL is where the real API redirects requests with a missing or expired SSO cookie.
+const loginURL = "https://profile.callofduty.com/cod/login"
+
+// FaultKind selects a simulated failure.
+type FaultKind int
+
+const (
+	// LoginRedirect answers with a 302 to the login page, as for an expired cookie.
+	LoginRedirect FaultKind = iota + 1
+	// HTMLLogin answers 200 with the login page's HTML instead of JSON.
+	HTMLLogin
+	// ErrorEnvelope answers 200 with {"status":"error"} and Fault.Message.
+	ErrorEnvelope
+	// RateLimited answers 429, with a Retry-After header when Fault.RetryAfter is set.
+	RateLimited
+	// ServerError answers with Fault.Status, or 503 when unset.
+	ServerError
+)
+
+// Fault is a simulated failure. Times limits how many requests it affects; zero means
+// every request until the fault is cleared.
+type Fault struct {
+	Kind       FaultKind
+	Message    string
+	Status     int
+	RetryAfter time.Duration
+	Times      int
+}
+
+// Server is a fake CoD API.
+type Server struct {
+	fixtures fs.FS
+	mux      *http.ServeMux
+
+	mu       sync.Mutex
+	faults   map[string]*Fault // keyed by lowercase gamertag; "" applies to every request
+	requests []string
+}
+
+// New creates a Server that serves responses from fixtures. See FixtureName for the
+// file layout.
+func New(fixtures fs.FS) *Server {
+	s := &Server{fixtures: fixtures, faults: make(map[string]*Fault)}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /stats/cod/v1/title/{title}/platform/{platform}/gamer/{gamertag}/profile/type/{mode}", s.profile)
+	s.mux.HandleFunc("GET /crm/cod/v2/title/{title}/platform/{platform}/gamer/{gamertag}/matches/{mode}/start/{start}/end/{end}/details", s.matches)
+	s.mux.HandleFunc("GET /crm/cod/v2/title/{title}/platform/{platform}/fullMatch/{mode}/{matchID}/{lang}", s.fullMatch)
+	return s
+}
+
+// Start runs the Server on a local httptest listener. Callers must Close it.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// Inject makes requests for gamertag fail with f. An empty gamertag applies f to every
+// request that has no gamertag-specific fault.
+func (s *Server) Inject(gamertag string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[strings.ToLower(gamertag)] = &f
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// Requests returns the paths requested so far, oldest first.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.Path)
+	s.mu.Unlock()
+
+	if c, err := r.Cookie("ACT_SSO_COOKIE"); err != nil || c.Value == "" {
+		http.Redirect(w, r, loginURL, http.StatusFound)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) profile(w http.ResponseWriter, r *http.Request) {
+	gamertag := r.PathValue("gamertag")
+	if s.fail(w, r, gamertag) {
+		return
+	}
+
+	name := FixtureName("profile", r.PathValue("title"), r.PathValue("platform"), gamertag, r.PathValue("mode"))
+	body, err := fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		writeErrorEnvelope(w, "Not permitted: user not found")
+		return
+	}
+	writeJSON(w, body)
+}
+
+func (s *Server) matches(w http.ResponseWriter, r *http.Request) {
+	gamertag := r.PathValue("gamertag")
+	if s.fail(w, r, gamertag) {
+		return
+	}
+
+	name := FixtureName("matches", r.PathValue("title"), r.PathValue("platform"), gamertag, r.PathValue("mode"))
+	body, err := fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		writeErrorEnvelope(w, "Not permitted: user not found")
+		return
+	}
+
+	start, _ := strconv.ParseInt(r.PathValue("start"), 10, 64)
+	end, _ := strconv.ParseInt(r.PathValue("end"), 10, 64)
+	page, err := pageMatches(body, start, end)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("bad fixture %s: %v", name, err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, page)
+}
+
+func (s *Server) fullMatch(w http.ResponseWriter, r *http.Request) {
+	if s.fail(w, r, "") {
+		return
+	}
+
+	name := FixtureName("fullmatch", r.PathValue("title"), r.PathValue("platform"), r.PathValue("matchID"))
+	body, err := fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		// The real endpoint reports unknown matches as an empty lobby
+		writeJSON(w, []byte(`{"status":"success","data":{"allPlayers":[]}}`))
+		return
+	}
+	writeJSON(w, body)
+}
+
+// fail writes the injected fault for gamertag, if any, and reports whether it did.
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, gamertag string) bool {
+	s.mu.Lock()
+	key := strings.ToLower(gamertag)
+	f, ok := s.faults[key]
+	if !ok {
+		key = ""
+		f, ok = s.faults[key]
+	}
+	if !ok {
+		s.mu.Unlock()
+		return false
+	}
+	fault := *f
+	if f.Times > 0 {
+		f.Times--
+		if f.Times == 0 {
+			delete(s.faults, key)
+		}
+	}
+	s.mu.Unlock()
+
+	switch fault.Kind {
+	case LoginRedirect:
+		http.Redirect(w, r, loginURL, http.StatusFound)
+	case HTMLLogin:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<!DOCTYPE html><html><head><title>Login | Call of Duty</title></head><body></body></html>")
+	case ErrorEnvelope:
+		writeErrorEnvelope(w, fault.Message)
+	case RateLimited:
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+		}
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+	default:
+		status := fault.Status
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, http.StatusText(status), status)
+	}
+	return true
+}
+
+// pageMatches narrows a matches fixture to the page the real API would return for the
+// window [start, end] in epoch milliseconds, where zero leaves that side open. Matches
+// are kept in fixture order, which should be newest first.
+func pageMatches(body []byte, start, end int64) ([]byte, error) {
+	var resp struct {
+		Status string         `json:"status"`
+		Data   map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	all, _ := resp.Data["matches"].([]any)
+
+	page := make([]any, 0, matchesPageSize)
+	for _, m := range all {
+		fields, _ := m.(map[string]any)
+		secs, _ := fields["utcStartSeconds"].(float64)
+		millis := int64(secs) * 1000
+		if (start > 0 && millis < start) || (end > 0 && millis > end) {
+			continue
+		}
+		page = append(page, m)
+		if len(page) == matchesPageSize {
+			break
+		}
+	}
+	resp.Data["matches"] = page
+	return json.Marshal(resp)
+}
+
+func writeJSON(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func writeErrorEnvelope(w http.ResponseWriter, msg string) {
+	body, _ := json.Marshal(map[string]any{
+		"status": "error",
+		"data":   map[string]string{"type": "com.activision.mt.common.stdtools.exceptions.NoStackTraceException", "message": msg},
+	})
+	writeJSON(w, body)
+}
+
+// FixtureName returns the fixture file for an endpoint kind ("profile", "matches" or
+// "fullmatch") and its path parameters, e.g. profile_mw_uno_player_1234_wz.json for
+// GET .../title/mw/platform/uno/gamer/Player%231234/profile/type/wz. Parameters are
+// lowercased and anything outside [a-z0-9-] becomes an underscore.
+func FixtureName(kind string, params ...string) string {
+	parts := make([]string, 0, len(params)+1)
+	parts = append(parts, kind)
+	for _, p := range params {
+		parts = append(parts, sanitize(p))
+	}
+	return strings.Join(parts, "_") + ".json"
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, s)
+}
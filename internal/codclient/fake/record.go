@@ -0,0 +1,141 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fixturePatterns map real endpoint paths to fixture kinds; submatches are the fixture
+// name parameters in FixtureName order.
+var fixturePatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"profile", regexp.MustCompile(`/stats/cod/v1/title/([^/]+)/platform/([^/]+)/gamer/([^/]+)/profile/type/([^/]+)$`)},
+	{"matches", regexp.MustCompile(`/crm/cod/v2/title/([^/]+)/platform/([^/]+)/gamer/([^/]+)/matches/([^/]+)/start/\d+/end/\d+/details$`)},
+	{"fullmatch", regexp.MustCompile(`/crm/cod/v2/title/([^/]+)/platform/([^/]+)/fullMatch/[^/]+/([^/]+)/[^/]+$`)},
+}
+
+// Recorder proxies requests to the real CoD API and saves successful JSON responses as
+// fixtures that Server can replay. Point a client at it with a valid SSO cookie to
+// capture real data.
+type Recorder struct {
+	upstream string
+	dir      string
+	http     *http.Client
+}
+
+// NewRecorder creates a Recorder forwarding to upstream (e.g.
+// https://my.callofduty.com/api/papi-client) and writing fixtures into dir.
+func NewRecorder(upstream, dir string) *Recorder {
+	return &Recorder{
+		upstream: strings.TrimSuffix(upstream, "/"),
+		dir:      dir,
+		http: &http.Client{
+			Timeout: 30 * time.Second,
+			// Pass redirects through so the client sees the login redirect itself
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, rec.upstream+r.URL.RequestURI(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, h := range []string{"Cookie", "User-Agent", "Accept"} {
+		if v := r.Header.Get(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+
+	resp, err := rec.http.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		if err := rec.save(r.URL.EscapedPath(), body); err != nil {
+			slog.Warn("fixture not recorded", "path", r.URL.Path, "error", err)
+		}
+	}
+
+	for _, h := range []string{"Content-Type", "Location", "Retry-After"} {
+		if v := resp.Header.Get(h); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+// save writes body to its fixture file if path is a known endpoint and body is a
+// successful JSON envelope.
+func (rec *Recorder) save(path string, body []byte) error {
+	name, ok := fixtureForPath(path)
+	if !ok {
+		return fmt.Errorf("no fixture mapping for path")
+	}
+
+	var envelope struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("response is not JSON: %w", err)
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("response status %q", envelope.Status)
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(rec.dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(rec.dir, name), pretty.Bytes(), 0o644); err != nil {
+		return err
+	}
+	slog.Info("recorded fixture", "file", name)
+	return nil
+}
+
+// fixtureForPath maps an escaped request path to its fixture name.
+func fixtureForPath(path string) (string, bool) {
+	for _, fp := range fixturePatterns {
+		m := fp.pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		params := make([]string, 0, len(m)-1)
+		for _, p := range m[1:] {
+			if unescaped, err := url.PathUnescape(p); err == nil {
+				p = unescaped
+			}
+			params = append(params, p)
+		}
+		return FixtureName(fp.kind, params...), true
+	}
+	return "", false
+}
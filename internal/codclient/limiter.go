@@ -0,0 +1,303 @@
+package codclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxLimiterPause is the longest a request will wait out an upstream Retry-After before
+// failing fast with ErrRateLimited instead.
+const maxLimiterPause = 15 * time.Second
+
+// Priority orders queued upstream requests. Lower values are served first.
+type Priority int
+
+const (
+	// PriorityInteractive is for requests a user is waiting on. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is for refreshes and bulk jobs that can wait.
+	PriorityBackground
+
+	numPriorities
+)
+
+func (p Priority) String() string {
+	if p == PriorityBackground {
+		return "background"
+	}
+	return "interactive"
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose upstream requests are queued at priority p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && p < numPriorities {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// RateLimit configures the client-side request budget. A zero PerMinute disables it.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+// LimiterStats describes the request budget and its queues.
+type LimiterStats struct {
+	Enabled     bool           `json:"enabled"`
+	PerMinute   int            `json:"perMinute,omitempty"`
+	Burst       int            `json:"burst,omitempty"`
+	Available   int            `json:"available"`
+	Queued      map[string]int `json:"queued"`
+	Granted     int64          `json:"granted"`
+	AvgWaitMs   int64          `json:"avgWaitMs"`
+	MaxWaitMs   int64          `json:"maxWaitMs"`
+	PausedUntil *time.Time     `json:"pausedUntil,omitempty"`
+}
+
+type limitWaiter struct {
+	ready    chan struct{}
+	queuedAt time.Time
+}
+
+// limiter is a token bucket shared by every upstream request. Waiting requests are
+// granted tokens strictly by priority, then in arrival order, by a dispatcher goroutine.
+// A 429 with Retry-After pauses the whole bucket.
+type limiter struct {
+	perSecond float64
+	burst     float64
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	queues      [numPriorities][]*limitWaiter
+	kick        chan struct{}
+
+	granted   int64
+	waitTotal time.Duration
+	waitMax   time.Duration
+}
+
+// newLimiter creates a limiter, or returns nil when rl disables limiting.
+func newLimiter(rl RateLimit) *limiter {
+	if rl.PerMinute <= 0 {
+		return nil
+	}
+	burst := max(1, rl.Burst)
+	l := &limiter{
+		perSecond: float64(rl.PerMinute) / 60,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+		kick:      make(chan struct{}, 1),
+	}
+	go l.dispatch()
+	return l
+}
+
+// wait blocks until the request may be sent or ctx ends. It fails fast with
+// ErrRateLimited if the upstream has asked us to back off for longer than maxLimiterPause.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.pausedUntil.Sub(now) > maxLimiterPause {
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	l.refill(now)
+	if l.queued() == 0 && !now.Before(l.pausedUntil) && l.tokens >= 1 {
+		l.tokens--
+		l.recordWait(0)
+		l.mu.Unlock()
+		return nil
+	}
+
+	p := priorityFrom(ctx)
+	w := &limitWaiter{ready: make(chan struct{}), queuedAt: now}
+	l.queues[p] = append(l.queues[p], w)
+	l.mu.Unlock()
+	l.wake()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-w.ready:
+			// Granted just as ctx ended; the token is spent either way
+		default:
+			l.remove(p, w)
+		}
+		return ctx.Err()
+	}
+}
+
+// pause stops granting tokens for d, as requested by an upstream Retry-After.
+func (l *limiter) pause(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+		l.tokens = 0
+	}
+	l.mu.Unlock()
+	l.wake()
+}
+
+func (l *limiter) stats() LimiterStats {
+	if l == nil {
+		return LimiterStats{Queued: map[string]int{}}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	s := LimiterStats{
+		Enabled:   true,
+		PerMinute: int(l.perSecond * 60),
+		Burst:     int(l.burst),
+		Available: int(l.tokens),
+		Queued:    make(map[string]int, numPriorities),
+		Granted:   l.granted,
+		MaxWaitMs: l.waitMax.Milliseconds(),
+	}
+	for p := range numPriorities {
+		s.Queued[p.String()] = len(l.queues[p])
+	}
+	if l.granted > 0 {
+		s.AvgWaitMs = (l.waitTotal / time.Duration(l.granted)).Milliseconds()
+	}
+	if time.Now().Before(l.pausedUntil) {
+		paused := l.pausedUntil
+		s.PausedUntil = &paused
+	}
+	return s
+}
+
+// dispatch hands out tokens to queued waiters as they accrue.
+func (l *limiter) dispatch() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+		if !now.Before(l.pausedUntil) {
+			for l.tokens >= 1 {
+				w := l.next()
+				if w == nil {
+					break
+				}
+				l.tokens--
+				l.recordWait(now.Sub(w.queuedAt))
+				close(w.ready)
+			}
+		}
+
+		delay := time.Hour
+		if l.queued() > 0 {
+			if now.Before(l.pausedUntil) {
+				delay = l.pausedUntil.Sub(now)
+			} else {
+				delay = time.Duration((1 - l.tokens) / l.perSecond * float64(time.Second))
+			}
+		}
+		l.mu.Unlock()
+
+		timer.Reset(max(delay, time.Millisecond))
+		select {
+		case <-timer.C:
+		case <-l.kick:
+		}
+	}
+}
+
+func (l *limiter) wake() {
+	select {
+	case l.kick <- struct{}{}:
+	default:
+	}
+}
+
+// refill adds the tokens accrued since the last refill. Callers must hold mu.
+func (l *limiter) refill(now time.Time) {
+	if now.Before(l.pausedUntil) {
+		l.last = now
+		return
+	}
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.perSecond)
+		l.last = now
+	}
+}
+
+// next pops the oldest waiter of the highest priority. Callers must hold mu.
+func (l *limiter) next() *limitWaiter {
+	for p := range numPriorities {
+		if len(l.queues[p]) > 0 {
+			w := l.queues[p][0]
+			l.queues[p] = l.queues[p][1:]
+			return w
+		}
+	}
+	return nil
+}
+
+// remove drops a waiter that gave up. Callers must hold mu.
+func (l *limiter) remove(p Priority, w *limitWaiter) {
+	for i, q := range l.queues[p] {
+		if q == w {
+			l.queues[p] = append(l.queues[p][:i], l.queues[p][i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *limiter) queued() int {
+	n := 0
+	for p := range numPriorities {
+		n += len(l.queues[p])
+	}
+	return n
+}
+
+func (l *limiter) recordWait(d time.Duration) {
+	l.granted++
+	l.waitTotal += d
+	l.waitMax = max(l.waitMax, d)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
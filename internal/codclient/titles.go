@@ -0,0 +1,141 @@
+package codclient
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultTitle is used when a request doesn't name a title.
+const DefaultTitle = "mw"
+
+// Title describes a supported game title: how to address it upstream, which modes it
+// accepts and where its profile payload keeps each lifetime stat.
+type Title struct {
+	ID      string     `json:"id"`      // title as accepted by this API
+	APICode string     `json:"apiCode"` // title segment of upstream URLs
+	Name    string     `json:"name"`
+	Modes   []string   `json:"modes"` // the first mode is the default
+	Fields  StatFields `json:"-"`
+}
+
+// DefaultMode returns the mode used when a request doesn't name one.
+func (t Title) DefaultMode() string {
+	return t.Modes[0]
+}
+
+// SupportsMode reports whether mode is valid for the title.
+func (t Title) SupportsMode(mode string) bool {
+	for _, m := range t.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// StatFields names the upstream lifetime properties each PlayerStats field is read from.
+// The same names are used for the per-mode breakdown.
+type StatFields struct {
+	Kills         string
+	Deaths        string
+	KDRatio       string
+	Wins          string
+	Losses        string
+	WinPct        string
+	ScorePerMin   string
+	Headshots     string
+	TimePlayed    string
+	MatchesPlayed string
+	TopFive       string
+	TopTen        string
+	TopTwentyFive string
+	Assists       string
+	DamageDone    string
+}
+
+// mwFields is the Modern Warfare (2019) profile schema the other titles are derived from.
+var mwFields = StatFields{
+	Kills:         "kills",
+	Deaths:        "deaths",
+	KDRatio:       "kdRatio",
+	Wins:          "wins",
+	Losses:        "losses",
+	WinPct:        "wlRatio",
+	ScorePerMin:   "scorePerMinute",
+	Headshots:     "headshots",
+	TimePlayed:    "timePlayed",
+	MatchesPlayed: "matchesPlayed",
+	TopFive:       "topFive",
+	TopTen:        "topTen",
+	TopTwentyFive: "topTwentyFive",
+	Assists:       "assists",
+	DamageDone:    "damageDone",
+}
+
+// mw2Fields covers the 2022 and later titles, which renamed the play count and time totals.
+var mw2Fields = func() StatFields {
+	f := mwFields
+	f.MatchesPlayed = "gamesPlayed"
+	f.TimePlayed = "timePlayedTotal"
+	return f
+}()
+
+var titles = map[string]Title{
+	"mw": {
+		ID:      "mw",
+		APICode: "mw",
+		Name:    "Modern Warfare (2019) / Warzone",
+		Modes:   []string{"wz", "mp"},
+		Fields:  mwFields,
+	},
+	"mw2": {
+		ID:      "mw2",
+		APICode: "mw2",
+		Name:    "Modern Warfare II / Warzone 2.0",
+		Modes:   []string{"wz2", "mp"},
+		Fields:  mw2Fields,
+	},
+	"mw3": {
+		ID:      "mw3",
+		APICode: "jup",
+		Name:    "Modern Warfare III / Warzone",
+		Modes:   []string{"wz", "mp"},
+		Fields:  mw2Fields,
+	},
+}
+
+// Titles lists every supported title ordered by ID.
+func Titles() []Title {
+	list := make([]Title, 0, len(titles))
+	for _, t := range titles {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// LookupTitle returns the registered title with the given ID.
+func LookupTitle(id string) (Title, bool) {
+	t, ok := titles[strings.ToLower(id)]
+	return t, ok
+}
+
+// ResolveTitle validates a title/mode pair against the registry, filling in the default
+// title and the title's default mode when either is empty.
+func ResolveTitle(title, mode string) (Title, string, error) {
+	if title == "" {
+		title = DefaultTitle
+	}
+	t, ok := LookupTitle(title)
+	if !ok {
+		return Title{}, "", &UnsupportedTitleError{Title: title, Mode: mode}
+	}
+	if mode == "" {
+		return t, t.DefaultMode(), nil
+	}
+	mode = strings.ToLower(mode)
+	if !t.SupportsMode(mode) {
+		return Title{}, "", &UnsupportedTitleError{Title: t.ID, Mode: mode}
+	}
+	return t, mode, nil
+}
@@ -0,0 +1,180 @@
+package codclient
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTokenName is the pool entry managed by UpdateToken and the COD_SSO_TOKEN env var.
+const DefaultTokenName = "default"
+
+// defaultTokenCooldown is how long a rate-limited token sits out before it's used again
+// when the upstream doesn't send a Retry-After.
+const defaultTokenCooldown = 15 * time.Minute
+
+// Token statuses reported by ListTokens.
+const (
+	TokenActive  = "active"
+	TokenBenched = "benched"
+	TokenRetired = "retired"
+)
+
+// TokenInfo describes a pooled SSO token without exposing its value.
+type TokenInfo struct {
+	Name         string     `json:"name"`
+	Status       string     `json:"status"`
+	Suffix       string     `json:"suffix"`
+	Requests     int        `json:"requests"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+	BenchedUntil *time.Time `json:"benchedUntil,omitempty"`
+	RetiredAt    *time.Time `json:"retiredAt,omitempty"`
+}
+
+type poolToken struct {
+	name         string
+	value        string
+	requests     int
+	lastUsedAt   time.Time
+	benchedUntil time.Time
+	retiredAt    time.Time
+}
+
+func (t *poolToken) usable(now time.Time) bool {
+	return t.retiredAt.IsZero() && !now.Before(t.benchedUntil)
+}
+
+// tokenPool spreads requests round-robin across named SSO tokens, benching tokens that
+// get rate limited and retiring tokens that have expired.
+type tokenPool struct {
+	mu       sync.Mutex
+	tokens   []*poolToken
+	next     int
+	cooldown time.Duration
+}
+
+func newTokenPool(cooldown time.Duration) *tokenPool {
+	return &tokenPool{cooldown: cooldown}
+}
+
+// acquire returns the next usable token. When none is usable it reports why: rate
+// limited if some token is only benched, otherwise expired.
+func (p *tokenPool) acquire() (*poolToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(p.tokens); i++ {
+		t := p.tokens[(p.next+i)%len(p.tokens)]
+		if t.usable(now) {
+			p.next = (p.next + i + 1) % len(p.tokens)
+			t.requests++
+			t.lastUsedAt = now
+			return t, nil
+		}
+	}
+
+	for _, t := range p.tokens {
+		if t.retiredAt.IsZero() {
+			return nil, ErrRateLimited
+		}
+	}
+	return nil, ErrTokenExpired
+}
+
+// size returns the number of tokens in the pool, including benched and retired ones.
+func (p *tokenPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.tokens)
+}
+
+// report benches or retires a token based on the error its request returned. A rate
+// limited token is benched for retryAfter when the upstream sent one, else the cooldown.
+func (p *tokenPool) report(t *poolToken, err error, retryAfter time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		bench := p.cooldown
+		if retryAfter > 0 {
+			bench = retryAfter
+		}
+		t.benchedUntil = time.Now().Add(bench)
+		slog.Warn("cod api token rate limited, benching", "token", t.name, "until", t.benchedUntil)
+	case errors.Is(err, ErrTokenExpired):
+		if t.retiredAt.IsZero() {
+			t.retiredAt = time.Now()
+			slog.Warn("cod api token expired, retiring", "token", t.name)
+		}
+	}
+}
+
+// put adds a token or replaces the value of an existing one, reactivating it.
+func (p *tokenPool) put(name, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, t := range p.tokens {
+		if t.name == name {
+			t.value = value
+			t.benchedUntil = time.Time{}
+			t.retiredAt = time.Time{}
+			return
+		}
+	}
+	p.tokens = append(p.tokens, &poolToken{name: name, value: value})
+}
+
+func (p *tokenPool) remove(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, t := range p.tokens {
+		if t.name == name {
+			p.tokens = append(p.tokens[:i], p.tokens[i+1:]...)
+			if p.next >= len(p.tokens) {
+				p.next = 0
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (p *tokenPool) list() []TokenInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	infos := make([]TokenInfo, 0, len(p.tokens))
+	for _, t := range p.tokens {
+		info := TokenInfo{
+			Name:     t.name,
+			Status:   TokenActive,
+			Suffix:   t.value[max(0, len(t.value)-4):],
+			Requests: t.requests,
+		}
+		if !t.lastUsedAt.IsZero() {
+			lastUsed := t.lastUsedAt
+			info.LastUsedAt = &lastUsed
+		}
+		switch {
+		case !t.retiredAt.IsZero():
+			retired := t.retiredAt
+			info.Status = TokenRetired
+			info.RetiredAt = &retired
+		case now.Before(t.benchedUntil):
+			benched := t.benchedUntil
+			info.Status = TokenBenched
+			info.BenchedUntil = &benched
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
@@ -1,6 +1,9 @@
 package codclient
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // PlayerStats represents lifetime player statistics from the CoD API.
 type PlayerStats struct {
@@ -24,6 +27,9 @@ type PlayerStats struct {
 	Assists       int                    `json:"assists"`
 	DamageDone    int                    `json:"damageDone"`
 	ModeBreakdown map[string]ModeStats   `json:"modeBreakdown,omitempty"`
+	Weapons       map[string]WeaponStats `json:"weapons,omitempty"`
+	WeaponClasses map[string]WeaponStats `json:"weaponClasses,omitempty"`
+	RawData       json.RawMessage        `json:"-"` // upstream profile payload, for storage only
 }
 
 // ModeStats represents per-mode statistics from the CoD API.
@@ -41,21 +47,70 @@ type ModeStats struct {
 	TopTwentyFive int     `json:"topTwentyFive"`
 }
 
+// WeaponStats represents lifetime statistics for one weapon, or summed across a weapon class.
+type WeaponStats struct {
+	Name      string  `json:"name,omitempty"`
+	Class     string  `json:"class"`
+	Kills     int     `json:"kills"`
+	Deaths    int     `json:"deaths"`
+	KDRatio   float64 `json:"kdRatio"`
+	Headshots int     `json:"headshots"`
+	Hits      int     `json:"hits"`
+	Shots     int     `json:"shots"`
+	Accuracy  float64 `json:"accuracy"`
+}
+
 // Match represents a single match from the CoD API.
 type Match struct {
-	MatchID     string    `json:"matchID"`
-	Mode        string    `json:"mode"`
-	Map         string    `json:"map"`
-	Placement   int       `json:"placement"`
-	Kills       int       `json:"kills"`
-	Deaths      int       `json:"deaths"`
-	KDRatio     float64   `json:"kdRatio"`
-	DamageDealt int       `json:"damageDealt"`
-	DamageTaken int       `json:"damageTaken"`
-	GulagResult string    `json:"gulagResult"`
-	Duration    int       `json:"duration"`
-	MatchTime   time.Time `json:"matchTime"`
-	RawData     any       `json:"rawData,omitempty"`
+	MatchID     string          `json:"matchID"`
+	Mode        string          `json:"mode"`
+	Map         string          `json:"map"`
+	Placement   int             `json:"placement"`
+	Kills       int             `json:"kills"`
+	Deaths      int             `json:"deaths"`
+	KDRatio     float64         `json:"kdRatio"`
+	DamageDealt int             `json:"damageDealt"`
+	DamageTaken int             `json:"damageTaken"`
+	GulagResult string          `json:"gulagResult"`
+	Duration    int             `json:"duration"`
+	MatchTime   time.Time       `json:"matchTime"`
+	RawData     json.RawMessage `json:"rawData,omitempty"` // upstream match payload
+
+	Headshots         int     `json:"headshots"`
+	Assists           int     `json:"assists"`
+	Score             int     `json:"score"`
+	TimePlayed        int     `json:"timePlayed"`        // seconds
+	DistanceTraveled  float64 `json:"distanceTraveled"`  // game units
+	PercentTimeMoving float64 `json:"percentTimeMoving"` // 0-100
+	Revives           int     `json:"revives"`
+	TeamWipes         int     `json:"teamWipes"`
+	CachesOpened      int     `json:"cachesOpened"`
+	Contracts         int     `json:"contracts"`
+	Cash              int     `json:"cash"`
+}
+
+// MatchDetails represents a full match lobby from the CoD fullMatch endpoint.
+type MatchDetails struct {
+	MatchID      string             `json:"matchID"`
+	Mode         string             `json:"mode"`
+	Map          string             `json:"map"`
+	Duration     int                `json:"duration"`
+	MatchTime    time.Time          `json:"matchTime"`
+	Participants []MatchParticipant `json:"participants"`
+}
+
+// MatchParticipant represents one player's result within a full match.
+type MatchParticipant struct {
+	Username    string  `json:"username"`
+	Clantag     string  `json:"clantag,omitempty"`
+	UnoID       string  `json:"unoId,omitempty"`
+	Team        string  `json:"team"`
+	Placement   int     `json:"placement"`
+	Kills       int     `json:"kills"`
+	Deaths      int     `json:"deaths"`
+	KDRatio     float64 `json:"kdRatio"`
+	DamageDealt int     `json:"damageDealt"`
+	DamageTaken int     `json:"damageTaken"`
 }
 
 // apiResponse is the wrapper returned by the CoD API.
@@ -71,32 +126,15 @@ type profileResponse struct {
 		Type     string `json:"type"`
 		Message  string `json:"message"`
 		Lifetime struct {
-			All  map[string]statsBlock `json:"all"`
-			Mode map[string]any        `json:"mode"`
+			All      map[string]map[string]any `json:"all"` // stats under "properties", keyed per Title.Fields
+			Mode     map[string]any            `json:"mode"`
+			ItemData map[string]any            `json:"itemData"`
 		} `json:"lifetime"`
 		Level    float64 `json:"level"`
 		Prestige float64 `json:"prestige"`
 	} `json:"data"`
 }
 
-type statsBlock struct {
-	Kills         float64 `json:"kills"`
-	Deaths        float64 `json:"deaths"`
-	KDRatio       float64 `json:"kdRatio"`
-	Wins          float64 `json:"wins"`
-	Losses        float64 `json:"losses"`
-	WinPct        float64 `json:"wlRatio"`
-	ScorePerMin   float64 `json:"scorePerMinute"`
-	Headshots     float64 `json:"headshots"`
-	TimePlayed    float64 `json:"timePlayed"`
-	MatchesPlayed float64 `json:"matchesPlayed"`
-	TopFive       float64 `json:"topFive"`
-	TopTen        float64 `json:"topTen"`
-	TopTwentyFive float64 `json:"topTwentyFive"`
-	Assists       float64 `json:"assists"`
-	DamageDone    float64 `json:"damageDone"`
-}
-
 // matchesResponse maps the matches endpoint response.
 type matchesResponse struct {
 	Status string `json:"status"`
@@ -113,16 +151,68 @@ type matchData struct {
 	PlayerStats   matchPlayerStats `json:"playerStats"`
 	Duration      int     `json:"duration"`
 	UTCStartSeconds float64 `json:"utcStartSeconds"`
-	RawData       any     `json:"-"`
+	RawData       json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a match while keeping a copy of its original payload in RawData.
+func (m *matchData) UnmarshalJSON(b []byte) error {
+	type plain matchData
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*m = matchData(p)
+	m.RawData = append(json.RawMessage(nil), b...)
+	return nil
 }
 
+// matchPlayerStats decodes every count as a float: the API sends the newer fields as 3.0
+// and the older ones as 3, and a float accepts either form, so the casts live in mapMatch.
 type matchPlayerStats struct {
-	Kills          int     `json:"kills"`
-	Deaths         int     `json:"deaths"`
+	Kills          float64 `json:"kills"`
+	Deaths         float64 `json:"deaths"`
 	KDRatio        float64 `json:"kdRatio"`
-	DamageDone     int     `json:"damageDone"`
-	DamageTaken    int     `json:"damageTaken"`
-	TeamPlacement  int     `json:"teamPlacement"`
-	GulagKills     int     `json:"gulagKills"`
-	GulagDeaths    int     `json:"gulagDeaths"`
+	DamageDone     float64 `json:"damageDone"`
+	DamageTaken    float64 `json:"damageTaken"`
+	TeamPlacement  float64 `json:"teamPlacement"`
+	GulagKills     float64 `json:"gulagKills"`
+	GulagDeaths    float64 `json:"gulagDeaths"`
+
+	Headshots                      float64 `json:"headshots"`
+	Assists                        float64 `json:"assists"`
+	Score                          float64 `json:"score"`
+	TimePlayed                     float64 `json:"timePlayed"`
+	DistanceTraveled               float64 `json:"distanceTraveled"`
+	PercentTimeMoving              float64 `json:"percentTimeMoving"`
+	ObjectiveReviver               float64 `json:"objectiveReviver"`
+	ObjectiveTeamWiped             float64 `json:"objectiveTeamWiped"`
+	ObjectiveBrCacheOpen           float64 `json:"objectiveBrCacheOpen"`
+	ObjectiveBrMissionPickupTablet float64 `json:"objectiveBrMissionPickupTablet"`
+	Cash                           float64 `json:"cash"`
+}
+
+// fullMatchResponse maps the fullMatch endpoint response.
+type fullMatchResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		AllPlayers []fullMatchPlayer `json:"allPlayers"`
+		Message    string            `json:"message"`
+	} `json:"data"`
+}
+
+type fullMatchPlayer struct {
+	MatchID         string            `json:"matchID"`
+	Mode            string            `json:"mode"`
+	Map             string            `json:"map"`
+	Duration        int               `json:"duration"`
+	UTCStartSeconds float64           `json:"utcStartSeconds"`
+	Player          fullMatchIdentity `json:"player"`
+	PlayerStats     matchPlayerStats  `json:"playerStats"`
+}
+
+type fullMatchIdentity struct {
+	Username string `json:"username"`
+	Clantag  string `json:"clantag"`
+	Uno      string `json:"uno"`
+	Team     string `json:"team"`
 }
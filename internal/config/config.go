@@ -1,10 +1,14 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -12,9 +16,26 @@ type Config struct {
 	DatabaseURL        string
 	CodAPIBaseURL      string
 	CodSSOToken        string
+	CodSSOTokens       string
+	CodRequestsPerMin  int
+	CodRequestBurst    int
+	CodTokenCooldown   time.Duration
+	DemoMode           bool
 	AdminAPIKey        string
 	CORSAllowedOrigins string
 	LogLevelStr        string
+	TrackerInterval    time.Duration
+	TrackerJitter      time.Duration
+	TokenKey           []byte
+	CacheBackend       string
+	CacheStatsSoftTTL  time.Duration
+	CacheStatsHardTTL  time.Duration
+	CacheMatchSoftTTL  time.Duration
+	CacheMatchHardTTL  time.Duration
+	CacheMaxEntries    int
+	CacheMaxBytes      int
+	CacheNotFoundTTL   time.Duration
+	CachePrivateTTL    time.Duration
 }
 
 func Load() (*Config, error) {
@@ -23,18 +44,68 @@ func Load() (*Config, error) {
 		DatabaseURL:        getEnv("DATABASE_URL", ""),
 		CodAPIBaseURL:      getEnv("COD_API_BASE_URL", "https://my.callofduty.com/api/papi-client"),
 		CodSSOToken:        getEnv("COD_SSO_TOKEN", ""),
+		CodSSOTokens:       getEnv("COD_SSO_TOKENS", ""),
+		CodRequestsPerMin:  getEnvInt("COD_REQUESTS_PER_MINUTE", 60),
+		CodRequestBurst:    getEnvInt("COD_REQUEST_BURST", 10),
+		CodTokenCooldown:   getEnvDuration("COD_TOKEN_COOLDOWN", 15*time.Minute),
+		DemoMode:           getEnvBool("DEMO_MODE", false),
 		AdminAPIKey:        getEnv("ADMIN_API_KEY", ""),
 		CORSAllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"),
 		LogLevelStr:        getEnv("LOG_LEVEL", "info"),
+		TrackerInterval:    getEnvDuration("TRACKER_INTERVAL", 30*time.Minute),
+		TrackerJitter:      getEnvDuration("TRACKER_JITTER", 5*time.Minute),
+		CacheBackend:       getEnv("CACHE_BACKEND", "memory"),
+		CacheStatsSoftTTL:  getEnvDuration("CACHE_STATS_SOFT_TTL", 5*time.Minute),
+		CacheStatsHardTTL:  getEnvDuration("CACHE_STATS_HARD_TTL", 1*time.Hour),
+		CacheMatchSoftTTL:  getEnvDuration("CACHE_MATCH_SOFT_TTL", 2*time.Minute),
+		CacheMatchHardTTL:  getEnvDuration("CACHE_MATCH_HARD_TTL", 30*time.Minute),
+		CacheMaxEntries:    getEnvInt("CACHE_MAX_ENTRIES", 10000),
+		CacheMaxBytes:      getEnvInt("CACHE_MAX_BYTES", 64<<20),
+		CacheNotFoundTTL:   getEnvDuration("CACHE_NOT_FOUND_TTL", 2*time.Minute),
+		CachePrivateTTL:    getEnvDuration("CACHE_PRIVATE_TTL", 10*time.Minute),
 	}
 
 	if cfg.DatabaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL is required")
 	}
 
+	switch cfg.CacheBackend {
+	case "memory", "postgres":
+	default:
+		return nil, fmt.Errorf("CACHE_BACKEND must be memory or postgres, got %q", cfg.CacheBackend)
+	}
+
+	if v := getEnv("TOKEN_ENCRYPTION_KEY", ""); v != "" {
+		key, err := parseKey(v)
+		if err != nil {
+			return nil, fmt.Errorf("TOKEN_ENCRYPTION_KEY: %w", err)
+		}
+		cfg.TokenKey = key
+	}
+
 	return cfg, nil
 }
 
+// SSOTokenPool parses COD_SSO_TOKENS ("name=token,name=token") into named tokens.
+// Malformed entries are skipped with a warning.
+func (c *Config) SSOTokenPool() map[string]string {
+	pool := make(map[string]string)
+	for _, entry := range strings.Split(c.CodSSOTokens, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, token, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || token == "" {
+			slog.Warn("ignoring malformed COD_SSO_TOKENS entry")
+			continue
+		}
+		pool[name] = token
+	}
+	return pool
+}
+
 func (c *Config) LogLevel() slog.Level {
 	switch c.LogLevelStr {
 	case "debug":
@@ -48,6 +119,17 @@ func (c *Config) LogLevel() slog.Level {
 	}
 }
 
+// parseKey decodes a 32-byte AES-256 key given as 64 hex characters or standard base64.
+func parseKey(v string) ([]byte, error) {
+	if key, err := hex.DecodeString(v); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(v); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("must be 32 bytes encoded as hex or base64")
+}
+
 func getEnv(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -63,3 +145,21 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,87 @@
+// Package freshness records where a response's data came from and how old it is.
+// Handlers attach a Recorder to the request context; the cache and services report
+// into it as they resolve data, without changing their return types.
+package freshness
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Source identifies where a response's data was loaded from.
+type Source string
+
+const (
+	Live       Source = "live"        // fetched from the CoD API for this request
+	Cache      Source = "cache"       // served from cache within its soft TTL
+	StaleCache Source = "stale_cache" // served from cache past its soft TTL or during an outage
+	Database   Source = "database"    // loaded from data stored in the database
+)
+
+// rank orders sources from freshest to stalest.
+var rank = map[Source]int{Live: 0, Cache: 1, StaleCache: 2, Database: 3}
+
+// Meta describes the provenance of a response.
+type Meta struct {
+	Source     Source    `json:"source"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+	AgeSeconds int       `json:"ageSeconds"`
+}
+
+// Recorder collects source reports for one request. When a response is assembled from
+// several loads, it keeps the stalest one. Recorders nest: a report reaches every Recorder
+// on the context, so a service can inspect a single load without hiding it from the handler.
+type Recorder struct {
+	parent *Recorder
+
+	mu        sync.Mutex
+	source    Source
+	fetchedAt time.Time
+	recorded  bool
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context carrying a new Recorder nested inside any already on ctx.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	parent, _ := ctx.Value(recorderKey{}).(*Recorder)
+	rec := &Recorder{parent: parent}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// Record reports that data was loaded from source as of fetchedAt. It is a no-op when
+// ctx carries no Recorder.
+func Record(ctx context.Context, source Source, fetchedAt time.Time) {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	for ; rec != nil; rec = rec.parent {
+		rec.record(source, fetchedAt)
+	}
+}
+
+func (r *Recorder) record(source Source, fetchedAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stalerSource := rank[source] > rank[r.source]
+	if !r.recorded || stalerSource || (source == r.source && fetchedAt.Before(r.fetchedAt)) {
+		r.source = source
+		r.fetchedAt = fetchedAt
+		r.recorded = true
+	}
+}
+
+// Meta returns the recorded provenance, or nil if nothing was recorded.
+func (r *Recorder) Meta() *Meta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.recorded {
+		return nil
+	}
+	age := int(time.Since(r.fetchedAt).Seconds())
+	if age < 0 || r.fetchedAt.IsZero() {
+		age = 0
+	}
+	return &Meta{Source: r.source, FetchedAt: r.fetchedAt, AgeSeconds: age}
+}
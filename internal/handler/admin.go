@@ -3,18 +3,24 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"regexp"
+	"strings"
 
-	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
 )
 
+var tokenNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,50}$`)
+
 // AdminHandler holds dependencies for admin endpoints.
 type AdminHandler struct {
-	codClient codclient.CodClient
+	tokenService *service.TokenService
 }
 
 // NewAdminHandler creates a new AdminHandler.
-func NewAdminHandler(codClient codclient.CodClient) *AdminHandler {
-	return &AdminHandler{codClient: codClient}
+func NewAdminHandler(tokenService *service.TokenService) *AdminHandler {
+	return &AdminHandler{tokenService: tokenService}
 }
 
 type updateTokenRequest struct {
@@ -44,7 +50,10 @@ func (h *AdminHandler) UpdateToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.codClient.UpdateToken(req.Token)
+	if err := h.tokenService.Update(r.Context(), req.Token); err != nil {
+		writeAPIError(w, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
@@ -52,3 +61,65 @@ func (h *AdminHandler) UpdateToken(w http.ResponseWriter, r *http.Request) {
 		"message": "SSO token updated successfully",
 	})
 }
+
+type addPoolTokenRequest struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+}
+
+// ListTokens handles GET /api/v1/admin/tokens to show the SSO token pool.
+func (h *AdminHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"tokens": h.tokenService.List()})
+}
+
+// AddToken handles POST /api/v1/admin/tokens to add or replace a named pool token.
+func (h *AdminHandler) AddToken(w http.ResponseWriter, r *http.Request) {
+	var req addPoolTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "Request body must contain a JSON object with 'name' and 'token' fields")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if !tokenNamePattern.MatchString(req.Name) {
+		writeBadRequest(w, "Token name must be 1-50 letters, digits, '.', '_' or '-'")
+		return
+	}
+	if req.Token == "" {
+		writeBadRequest(w, "Token must not be empty")
+		return
+	}
+
+	if err := h.tokenService.Add(r.Context(), req.Name, req.Token); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":  "ok",
+		"message": "SSO token added to pool",
+	})
+}
+
+// RemoveToken handles DELETE /api/v1/admin/tokens/{name} to drop a pool token.
+func (h *AdminHandler) RemoveToken(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	removed, err := h.tokenService.Remove(r.Context(), name)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !removed {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(apiError{
+			Error:   "token_not_found",
+			Message: "No pool token with that name",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+// BackfillHandler holds dependencies for the match backfill admin endpoints.
+type BackfillHandler struct {
+	backfillService *service.BackfillService
+}
+
+// NewBackfillHandler creates a new BackfillHandler.
+func NewBackfillHandler(backfillService *service.BackfillService) *BackfillHandler {
+	return &BackfillHandler{backfillService: backfillService}
+}
+
+type backfillRequest struct {
+	Platform string `json:"platform"`
+	Gamertag string `json:"gamertag"`
+	Title    string `json:"title"`
+	Mode     string `json:"mode"`
+	Restart  bool   `json:"restart"`
+}
+
+// StartBackfill handles POST /api/v1/admin/backfill
+func (h *BackfillHandler) StartBackfill(w http.ResponseWriter, r *http.Request) {
+	var req backfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "Request body must contain a JSON object with 'platform' and 'gamertag' fields")
+		return
+	}
+	req.Gamertag = strings.TrimSpace(req.Gamertag)
+	if req.Gamertag == "" {
+		writeBadRequest(w, "gamertag must not be empty")
+		return
+	}
+	if req.Platform == "" {
+		req.Platform = "uno"
+	}
+
+	progress, err := h.backfillService.Start(r.Context(), req.Platform, req.Gamertag, req.Title, req.Mode, req.Restart)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(progress)
+}
+
+// GetBackfill handles GET /api/v1/admin/backfill/{platform}/{gamertag}?title=&mode=
+func (h *BackfillHandler) GetBackfill(w http.ResponseWriter, r *http.Request) {
+	platform := chi.URLParam(r, "platform")
+	gamertag := chi.URLParam(r, "gamertag")
+	title := r.URL.Query().Get("title")
+	mode := r.URL.Query().Get("mode")
+
+	progress, err := h.backfillService.Status(r.Context(), platform, gamertag, title, mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progress)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/cache"
+)
+
+// CacheHandler holds dependencies for cache admin endpoints.
+type CacheHandler struct {
+	cachedClient *cache.CachedClient
+}
+
+// NewCacheHandler creates a new CacheHandler.
+func NewCacheHandler(cachedClient *cache.CachedClient) *CacheHandler {
+	return &CacheHandler{cachedClient: cachedClient}
+}
+
+// ClearNegative handles DELETE /api/v1/admin/cache/negative/{platform}/{gamertag}?title=&mode= to
+// forget a cached not-found or private-profile result.
+func (h *CacheHandler) ClearNegative(w http.ResponseWriter, r *http.Request) {
+	platform := chi.URLParam(r, "platform")
+	gamertag := chi.URLParam(r, "gamertag")
+	title := r.URL.Query().Get("title")
+	mode := r.URL.Query().Get("mode")
+
+	cleared, err := h.cachedClient.ClearNegative(r.Context(), platform, gamertag, title, mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !cleared {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(apiError{
+			Error:   "not_cached",
+			Message: "No negative cache entry for that player",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
 )
 
 type apiError struct {
@@ -16,17 +17,34 @@ type apiError struct {
 
 // writeAPIError maps codclient sentinel errors to HTTP status codes and writes a JSON response.
 func writeAPIError(w http.ResponseWriter, err error) {
-	var (
-		status int
-		code   string
-		msg    string
-	)
+	status, code, msg := classifyError(err)
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiError{Error: code, Message: msg})
+}
+
+// entryStatus returns "ok" for a nil err and otherwise the error code writeAPIError
+// would report, for endpoints that report failures per entry instead of failing the request.
+func entryStatus(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	_, code, _ := classifyError(err)
+	return code
+}
+
+// classifyError maps an error to its HTTP status, error code and user-facing message.
+func classifyError(err error) (status int, code, msg string) {
 	switch {
 	case errors.Is(err, codclient.ErrPlayerNotFound):
 		status = http.StatusNotFound
 		code = "player_not_found"
 		msg = "Player not found"
+	case errors.Is(err, codclient.ErrMatchNotFound):
+		status = http.StatusNotFound
+		code = "match_not_found"
+		msg = "Match not found"
 	case errors.Is(err, codclient.ErrPrivateProfile):
 		status = http.StatusForbidden
 		code = "private_profile"
@@ -43,14 +61,50 @@ func writeAPIError(w http.ResponseWriter, err error) {
 		status = http.StatusTooManyRequests
 		code = "rate_limited"
 		msg = "Too many requests to CoD API"
+	case errors.Is(err, codclient.ErrUnsupportedTitle):
+		status = http.StatusBadRequest
+		code = "unsupported_title"
+		msg = err.Error()
+	case errors.Is(err, service.ErrInvalidQuery):
+		status = http.StatusBadRequest
+		code = "invalid_request"
+		msg = err.Error()
+	case errors.Is(err, service.ErrNotTracked):
+		status = http.StatusNotFound
+		code = "not_tracked"
+		msg = "Player is not tracked"
+	case errors.Is(err, service.ErrBackfillRunning):
+		status = http.StatusConflict
+		code = "backfill_running"
+		msg = "A backfill is already running for this player"
+	case errors.Is(err, service.ErrReprocessRunning):
+		status = http.StatusConflict
+		code = "reprocess_running"
+		msg = "A reprocess job is already running"
+	case errors.Is(err, service.ErrBackfillNotFound):
+		status = http.StatusNotFound
+		code = "backfill_not_found"
+		msg = "No backfill has been started for this player"
+	case errors.Is(err, service.ErrSquadNotFound):
+		status = http.StatusNotFound
+		code = "squad_not_found"
+		msg = "Squad not found"
+	case errors.Is(err, service.ErrSquadFull):
+		status = http.StatusConflict
+		code = "squad_full"
+		msg = "Squad has reached the maximum number of members"
 	default:
 		slog.Error("unhandled error in API handler", "error", err)
 		status = http.StatusInternalServerError
 		code = "internal_error"
 		msg = "An unexpected error occurred"
 	}
+	return status, code, msg
+}
 
+// writeBadRequest writes a 400 invalid_request response with the given message.
+func writeBadRequest(w http.ResponseWriter, msg string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(apiError{Error: code, Message: msg})
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(apiError{Error: "invalid_request", Message: msg})
 }
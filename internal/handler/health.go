@@ -3,10 +3,14 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
 )
 
 type HealthResponse struct {
-	Status string `json:"status"`
+	Status   string                  `json:"status"`
+	Circuits []codclient.CircuitInfo `json:"circuits,omitempty"`
+	Limiter  *codclient.LimiterStats `json:"limiter,omitempty"`
 }
 
 func Health(w http.ResponseWriter, r *http.Request) {
@@ -14,6 +18,33 @@ func Health(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(HealthResponse{Status: "ok"})
 }
 
+// HealthHandler reports service health along with the CoD API circuit breakers and
+// request budget.
+type HealthHandler struct {
+	codClient codclient.CodClient
+}
+
+// NewHealthHandler creates a new HealthHandler.
+func NewHealthHandler(codClient codclient.CodClient) *HealthHandler {
+	return &HealthHandler{codClient: codClient}
+}
+
+// Health handles GET /api/v1/health. The status is "degraded" while any circuit is
+// not closed; it still returns 200 since the service itself can serve cached data.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	limiter := h.codClient.LimiterStats()
+	resp := HealthResponse{Status: "ok", Circuits: h.codClient.CircuitStates(), Limiter: &limiter}
+	for _, c := range resp.Circuits {
+		if c.State != codclient.CircuitClosed {
+			resp.Status = "degraded"
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 func NotImplemented(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusNotImplemented)
@@ -1,15 +1,19 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
+	"regexp"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
 	"github.com/grovecj/warzone-stats-tracker/internal/service"
 )
 
+var matchIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,100}$`)
+
 // MatchHandler holds dependencies for match endpoints.
 type MatchHandler struct {
 	matchService *service.MatchService
@@ -41,12 +45,34 @@ func (h *MatchHandler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	title := r.URL.Query().Get("title")
 	mode := r.URL.Query().Get("mode")
 
-	result, err := h.matchService.GetRecentMatches(r.Context(), platform, gamertag, title, mode, limit, offset)
+	ctx, rec := freshness.WithRecorder(r.Context())
+	result, err := h.matchService.GetRecentMatches(ctx, platform, gamertag, title, mode, limit, offset)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	meta := rec.Meta()
+	writeJSONWithMeta(w, meta, matchListResponse{MatchListResult: result, Meta: meta})
+}
+
+// GetMatchDetails handles GET /api/v1/matches/{matchID}?title=&platform=
+func (h *MatchHandler) GetMatchDetails(w http.ResponseWriter, r *http.Request) {
+	matchID := chi.URLParam(r, "matchID")
+	if !matchIDPattern.MatchString(matchID) {
+		writeAPIError(w, codclient.ErrMatchNotFound)
+		return
+	}
+	title := r.URL.Query().Get("title")
+	platform := r.URL.Query().Get("platform")
+
+	ctx, rec := freshness.WithRecorder(r.Context())
+	details, err := h.matchService.GetMatchDetails(ctx, matchID, title, platform)
 	if err != nil {
 		writeAPIError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	meta := rec.Meta()
+	writeJSONWithMeta(w, meta, matchDetailsResponse{MatchDetails: details, Meta: meta})
 }
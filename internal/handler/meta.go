@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+// xCacheValues maps a data source to the X-Cache header value.
+var xCacheValues = map[freshness.Source]string{
+	freshness.Live:       "MISS",
+	freshness.Cache:      "HIT",
+	freshness.StaleCache: "STALE",
+	freshness.Database:   "DATABASE",
+}
+
+// Response wrappers add a meta object alongside the existing fields.
+type statsResponse struct {
+	*codclient.PlayerStats
+	Meta *freshness.Meta `json:"meta,omitempty"`
+}
+
+type searchResponse struct {
+	*service.PlayerSearchResult
+	Meta *freshness.Meta `json:"meta,omitempty"`
+}
+
+type matchListResponse struct {
+	*service.MatchListResult
+	Meta *freshness.Meta `json:"meta,omitempty"`
+}
+
+type matchDetailsResponse struct {
+	*model.MatchDetails
+	Meta *freshness.Meta `json:"meta,omitempty"`
+}
+
+// writeJSONWithMeta sets the X-Cache and X-Data-Age headers from meta and encodes body.
+// A nil meta (nothing was recorded) leaves the headers unset.
+func writeJSONWithMeta(w http.ResponseWriter, meta *freshness.Meta, body any) {
+	if meta != nil {
+		w.Header().Set("X-Cache", xCacheValues[meta.Source])
+		w.Header().Set("X-Data-Age", strconv.Itoa(meta.AgeSeconds))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
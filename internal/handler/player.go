@@ -2,10 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
 	"github.com/grovecj/warzone-stats-tracker/internal/service"
 )
 
@@ -41,14 +45,15 @@ func (h *PlayerHandler) SearchPlayer(w http.ResponseWriter, r *http.Request) {
 	title := r.URL.Query().Get("title")
 	mode := r.URL.Query().Get("mode")
 
-	result, err := h.playerService.SearchPlayer(r.Context(), platform, gamertag, title, mode)
+	ctx, rec := freshness.WithRecorder(r.Context())
+	result, err := h.playerService.SearchPlayer(ctx, platform, gamertag, title, mode)
 	if err != nil {
 		writeAPIError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	meta := rec.Meta()
+	writeJSONWithMeta(w, meta, searchResponse{PlayerSearchResult: result, Meta: meta})
 }
 
 // GetStats handles GET /api/v1/players/{platform}/{gamertag}/stats?mode=
@@ -58,12 +63,156 @@ func (h *PlayerHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	title := r.URL.Query().Get("title")
 	mode := r.URL.Query().Get("mode")
 
-	stats, err := h.playerService.GetPlayerStats(r.Context(), platform, gamertag, title, mode)
+	ctx, rec := freshness.WithRecorder(r.Context())
+	stats, err := h.playerService.GetPlayerStats(ctx, platform, gamertag, title, mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	meta := rec.Meta()
+	writeJSONWithMeta(w, meta, statsResponse{PlayerStats: stats, Meta: meta})
+}
+
+// ComparePlayers handles GET /api/v1/compare?players=platform:gamertag,platform:gamertag&title=&mode=
+func (h *PlayerHandler) ComparePlayers(w http.ResponseWriter, r *http.Request) {
+	refs, err := parsePlayerRefs(r.URL.Query().Get("players"))
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	title := r.URL.Query().Get("title")
+	mode := r.URL.Query().Get("mode")
+
+	result, errs, err := h.playerService.ComparePlayers(r.Context(), refs, title, mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	resp := compareResponse{CompareResult: result, Players: make([]comparedPlayer, len(result.Players))}
+	for i, p := range result.Players {
+		resp.Players[i] = comparedPlayer{ComparedPlayer: p, Status: entryStatus(errs[i])}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// compareResponse replaces the result's players with ones carrying a per-player status.
+type compareResponse struct {
+	*service.CompareResult
+	Players []comparedPlayer `json:"players"`
+}
+
+type comparedPlayer struct {
+	service.ComparedPlayer
+	Status string `json:"status"`
+}
+
+// parsePlayerRefs parses a comma-separated list of platform:gamertag pairs.
+// A missing platform defaults to "uno", matching SearchPlayer.
+func parsePlayerRefs(raw string) ([]service.PlayerRef, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("players query parameter is required")
+	}
+
+	var refs []service.PlayerRef
+	seen := make(map[service.PlayerRef]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		ref := service.PlayerRef{Platform: "uno", Gamertag: part}
+		if platform, gamertag, ok := strings.Cut(part, ":"); ok {
+			ref = service.PlayerRef{Platform: strings.TrimSpace(platform), Gamertag: strings.TrimSpace(gamertag)}
+		}
+		if ref.Platform == "" || ref.Gamertag == "" {
+			return nil, fmt.Errorf("invalid player %q, expected platform:gamertag", part)
+		}
+		if seen[ref] {
+			return nil, fmt.Errorf("player %q is listed more than once", part)
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	if len(refs) < 2 {
+		return nil, fmt.Errorf("at least 2 players are required for comparison")
+	}
+	if len(refs) > service.MaxComparePlayers {
+		return nil, fmt.Errorf("at most %d players can be compared at once", service.MaxComparePlayers)
+	}
+	return refs, nil
+}
+
+// GetStatsHistory handles GET /api/v1/players/{platform}/{gamertag}/stats/history?title=&mode=&from=&to=&bucket=&metrics=
+func (h *PlayerHandler) GetStatsHistory(w http.ResponseWriter, r *http.Request) {
+	platform := chi.URLParam(r, "platform")
+	gamertag := chi.URLParam(r, "gamertag")
+
+	q := service.StatsHistoryQuery{
+		Title:  r.URL.Query().Get("title"),
+		Mode:   r.URL.Query().Get("mode"),
+		Bucket: r.URL.Query().Get("bucket"),
+	}
+
+	var err error
+	if q.From, err = parseTimeParam(r.URL.Query().Get("from")); err != nil {
+		writeBadRequest(w, "from must be an RFC 3339 timestamp or YYYY-MM-DD date")
+		return
+	}
+	if q.To, err = parseTimeParam(r.URL.Query().Get("to")); err != nil {
+		writeBadRequest(w, "to must be an RFC 3339 timestamp or YYYY-MM-DD date")
+		return
+	}
+	if v := r.URL.Query().Get("metrics"); v != "" {
+		for _, m := range strings.Split(v, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				q.Metrics = append(q.Metrics, m)
+			}
+		}
+	}
+
+	result, err := h.playerService.GetStatsHistory(r.Context(), platform, gamertag, q)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// parseTimeParam accepts an RFC 3339 timestamp or a plain date (UTC midnight).
+// An empty value yields the zero time.
+func parseTimeParam(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
+
+// GetWeapons handles GET /api/v1/players/{platform}/{gamertag}/weapons?sort=&class=&title=&mode=
+func (h *PlayerHandler) GetWeapons(w http.ResponseWriter, r *http.Request) {
+	platform := chi.URLParam(r, "platform")
+	gamertag := chi.URLParam(r, "gamertag")
+	title := r.URL.Query().Get("title")
+	mode := r.URL.Query().Get("mode")
+	sortBy := r.URL.Query().Get("sort")
+	class := r.URL.Query().Get("class")
+
+	result, err := h.playerService.GetWeaponStats(r.Context(), platform, gamertag, title, mode, sortBy, class)
 	if err != nil {
 		writeAPIError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(stats)
+	json.NewEncoder(w).Encode(result)
 }
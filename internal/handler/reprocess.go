@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+// ReprocessHandler holds dependencies for the raw payload reprocessing admin endpoints.
+type ReprocessHandler struct {
+	reprocessService *service.ReprocessService
+}
+
+// NewReprocessHandler creates a new ReprocessHandler.
+func NewReprocessHandler(reprocessService *service.ReprocessService) *ReprocessHandler {
+	return &ReprocessHandler{reprocessService: reprocessService}
+}
+
+type reprocessRequest struct {
+	Target string `json:"target"`
+}
+
+// StartReprocess handles POST /api/v1/admin/reprocess
+func (h *ReprocessHandler) StartReprocess(w http.ResponseWriter, r *http.Request) {
+	var req reprocessRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeBadRequest(w, "Request body must be a JSON object with an optional 'target' field")
+			return
+		}
+	}
+
+	status, err := h.reprocessService.Start(req.Target)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(status)
+}
+
+// GetReprocess handles GET /api/v1/admin/reprocess
+func (h *ReprocessHandler) GetReprocess(w http.ResponseWriter, r *http.Request) {
+	status := h.reprocessService.Status()
+	if status == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(apiError{Error: "reprocess_not_found", Message: "No reprocess job has run"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
@@ -1,4 +1,223 @@
 package handler
 
-// Squad HTTP handlers will be implemented in issue #16.
-// Route stubs are registered in router/router.go using handler.NotImplemented.
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+const maxSquadNameLength = 100
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// SquadHandler holds dependencies for squad endpoints.
+type SquadHandler struct {
+	squadService *service.SquadService
+}
+
+// NewSquadHandler creates a new SquadHandler.
+func NewSquadHandler(squadService *service.SquadService) *SquadHandler {
+	return &SquadHandler{squadService: squadService}
+}
+
+type squadRequest struct {
+	Name string `json:"name"`
+}
+
+type addMemberRequest struct {
+	Platform string `json:"platform"`
+	Gamertag string `json:"gamertag"`
+}
+
+// CreateSquad handles POST /api/v1/squads
+func (h *SquadHandler) CreateSquad(w http.ResponseWriter, r *http.Request) {
+	name, ok := decodeSquadName(w, r)
+	if !ok {
+		return
+	}
+
+	squad, err := h.squadService.CreateSquad(r.Context(), name)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(squad)
+}
+
+// GetSquad handles GET /api/v1/squads/{squadID}
+func (h *SquadHandler) GetSquad(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	squad, err := h.squadService.GetSquad(r.Context(), squadID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(squad)
+}
+
+// UpdateSquad handles PUT /api/v1/squads/{squadID}
+func (h *SquadHandler) UpdateSquad(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+	name, ok := decodeSquadName(w, r)
+	if !ok {
+		return
+	}
+
+	squad, err := h.squadService.RenameSquad(r.Context(), squadID, name)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(squad)
+}
+
+// DeleteSquad handles DELETE /api/v1/squads/{squadID}
+func (h *SquadHandler) DeleteSquad(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.squadService.DeleteSquad(r.Context(), squadID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddMember handles POST /api/v1/squads/{squadID}/members
+func (h *SquadHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req addMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "Request body must contain a JSON object with 'platform' and 'gamertag' fields")
+		return
+	}
+	req.Gamertag = strings.TrimSpace(req.Gamertag)
+	if req.Gamertag == "" {
+		writeBadRequest(w, "gamertag must not be empty")
+		return
+	}
+	if req.Platform == "" {
+		req.Platform = "uno"
+	}
+
+	squad, err := h.squadService.AddMember(r.Context(), squadID, req.Platform, req.Gamertag)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(squad)
+}
+
+// RemoveMember handles DELETE /api/v1/squads/{squadID}/members/{playerID}
+func (h *SquadHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+	playerID := chi.URLParam(r, "playerID")
+	if !uuidPattern.MatchString(playerID) {
+		writeBadRequest(w, "playerID must be a valid UUID")
+		return
+	}
+
+	if err := h.squadService.RemoveMember(r.Context(), squadID, playerID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// squadIDParam reads the squadID URL parameter, writing a 404 if it can't be a valid squad.
+func squadIDParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	squadID := chi.URLParam(r, "squadID")
+	if !uuidPattern.MatchString(squadID) {
+		writeAPIError(w, service.ErrSquadNotFound)
+		return "", false
+	}
+	return squadID, true
+}
+
+// decodeSquadName reads and validates the squad name from the request body.
+func decodeSquadName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req squadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "Request body must contain a JSON object with a 'name' field")
+		return "", false
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		writeBadRequest(w, "Squad name must not be empty")
+		return "", false
+	}
+	if utf8.RuneCountInString(name) > maxSquadNameLength {
+		writeBadRequest(w, "Squad name must be at most 100 characters")
+		return "", false
+	}
+	return name, true
+}
+
+// GetSquadStats handles GET /api/v1/squads/{squadID}/stats?title=&mode=
+func (h *SquadHandler) GetSquadStats(w http.ResponseWriter, r *http.Request) {
+	squadID, ok := squadIDParam(w, r)
+	if !ok {
+		return
+	}
+	title := r.URL.Query().Get("title")
+	mode := r.URL.Query().Get("mode")
+
+	result, errs, err := h.squadService.GetSquadStats(r.Context(), squadID, title, mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	resp := squadStatsResponse{SquadStatsResult: result, Members: make([]squadMemberStats, len(result.Members))}
+	for i, m := range result.Members {
+		resp.Members[i] = squadMemberStats{SquadMemberStats: m, Status: entryStatus(errs[i])}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// squadStatsResponse replaces the result's members with ones carrying a per-member status.
+type squadStatsResponse struct {
+	*service.SquadStatsResult
+	Members []squadMemberStats `json:"members"`
+}
+
+type squadMemberStats struct {
+	service.SquadMemberStats
+	Status string `json:"status"`
+}
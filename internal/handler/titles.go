@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+type titlesResponse struct {
+	Default string            `json:"default"`
+	Titles  []codclient.Title `json:"titles"`
+}
+
+// Titles handles GET /api/v1/titles, listing the titles and modes requests may name.
+func Titles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(titlesResponse{Default: codclient.DefaultTitle, Titles: codclient.Titles()})
+}
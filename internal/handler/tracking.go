@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+// TrackingHandler holds dependencies for the tracked player admin endpoints.
+type TrackingHandler struct {
+	trackingService *service.TrackingService
+}
+
+// NewTrackingHandler creates a new TrackingHandler.
+func NewTrackingHandler(trackingService *service.TrackingService) *TrackingHandler {
+	return &TrackingHandler{trackingService: trackingService}
+}
+
+type followRequest struct {
+	Platform string `json:"platform"`
+	Gamertag string `json:"gamertag"`
+	Title    string `json:"title"`
+	Mode     string `json:"mode"`
+}
+
+// ListTracked handles GET /api/v1/admin/tracked
+func (h *TrackingHandler) ListTracked(w http.ResponseWriter, r *http.Request) {
+	tracked, err := h.trackingService.List(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if tracked == nil {
+		tracked = []model.TrackedPlayer{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"players": tracked})
+}
+
+// Follow handles POST /api/v1/admin/tracked
+func (h *TrackingHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	var req followRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "Request body must contain a JSON object with 'platform' and 'gamertag' fields")
+		return
+	}
+	req.Gamertag = strings.TrimSpace(req.Gamertag)
+	if req.Gamertag == "" {
+		writeBadRequest(w, "gamertag must not be empty")
+		return
+	}
+	if req.Platform == "" {
+		req.Platform = "uno"
+	}
+
+	tracked, err := h.trackingService.Follow(r.Context(), req.Platform, req.Gamertag, req.Title, req.Mode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tracked)
+}
+
+// Unfollow handles DELETE /api/v1/admin/tracked/{playerID}
+func (h *TrackingHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	playerID := chi.URLParam(r, "playerID")
+	if !uuidPattern.MatchString(playerID) {
+		writeAPIError(w, service.ErrNotTracked)
+		return
+	}
+
+	if err := h.trackingService.Unfollow(r.Context(), playerID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Backfill statuses.
+const (
+	BackfillRunning  = "running"
+	BackfillPaused   = "paused"
+	BackfillComplete = "complete"
+)
+
+// BackfillProgress records how far back a player's match history has been fetched.
+// CursorEnd is the end of the next window to request; nil means start from now.
+type BackfillProgress struct {
+	PlayerID     string     `json:"playerId"`
+	Title        string     `json:"title"`
+	Mode         string     `json:"mode"`
+	Status       string     `json:"status"`
+	CursorEnd    *time.Time `json:"cursorEnd,omitempty"`
+	PagesFetched int        `json:"pagesFetched"`
+	MatchesFound int        `json:"matchesFound"`
+	LastError    *string    `json:"lastError,omitempty"`
+	StartedAt    time.Time  `json:"startedAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+}
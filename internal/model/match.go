@@ -1,11 +1,15 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Match struct {
 	ID          string    `json:"id"`
 	MatchID     string    `json:"matchId"`
 	PlayerID    string    `json:"playerId"`
+	Title       string    `json:"title"`
 	Mode        string    `json:"mode"`
 	MapName     string    `json:"mapName"`
 	Placement   int       `json:"placement"`
@@ -16,4 +20,21 @@ type Match struct {
 	GulagResult string    `json:"gulagResult,omitempty"`
 	MatchTime   time.Time `json:"matchTime"`
 	CreatedAt   time.Time `json:"createdAt"`
+
+	// Extended stats are nil for matches stored before they were extracted, until the
+	// reprocess job re-derives them from the raw payload.
+	Headshots         *int     `json:"headshots,omitempty"`
+	Assists           *int     `json:"assists,omitempty"`
+	Score             *int     `json:"score,omitempty"`
+	TimePlayed        *int     `json:"timePlayed,omitempty"`
+	DistanceTraveled  *float64 `json:"distanceTraveled,omitempty"`
+	PercentTimeMoving *float64 `json:"percentTimeMoving,omitempty"`
+	Revives           *int     `json:"revives,omitempty"`
+	TeamWipes         *int     `json:"teamWipes,omitempty"`
+	CachesOpened      *int     `json:"cachesOpened,omitempty"`
+	Contracts         *int     `json:"contracts,omitempty"`
+	Cash              *int     `json:"cash,omitempty"`
+
+	// RawData is the upstream match payload, stored for reprocessing but never returned.
+	RawData json.RawMessage `json:"-"`
 }
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type MatchDetails struct {
+	MatchID      string             `json:"matchId"`
+	Title        string             `json:"title"`
+	Mode         string             `json:"mode"`
+	MapName      string             `json:"mapName"`
+	Duration     int                `json:"duration"`
+	MatchTime    time.Time          `json:"matchTime"`
+	FetchedAt    time.Time          `json:"fetchedAt"`
+	Participants []MatchParticipant `json:"participants"`
+
+	// Players lists the stored players whose match history includes this match.
+	Players []Player `json:"players,omitempty"`
+}
+
+type MatchParticipant struct {
+	Username    string  `json:"username"`
+	Clantag     string  `json:"clantag,omitempty"`
+	UnoID       string  `json:"unoId,omitempty"`
+	Team        string  `json:"team"`
+	Placement   int     `json:"placement"`
+	Kills       int     `json:"kills"`
+	Deaths      int     `json:"deaths"`
+	KDRatio     float64 `json:"kdRatio"`
+	DamageDealt int     `json:"damageDealt"`
+	DamageTaken int     `json:"damageTaken"`
+}
@@ -5,7 +5,18 @@ import "time"
 type PlayerStatsSnapshot struct {
 	ID        string    `json:"id"`
 	PlayerID  string    `json:"playerId"`
+	Title     string    `json:"title"`
 	Mode      string    `json:"mode"`
 	StatsData any       `json:"statsData"`
 	FetchedAt time.Time `json:"fetchedAt"`
 }
+
+// RawPayload is a stored upstream payload along with the row it was derived into.
+type RawPayload struct {
+	ID       string
+	PlayerID string
+	Platform string
+	Gamertag string
+	Title    string
+	Data     []byte
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type TrackedPlayer struct {
+	PlayerID        string     `json:"playerId"`
+	Platform        string     `json:"platform"`
+	Gamertag        string     `json:"gamertag"`
+	Title           string     `json:"title"`
+	Mode            string     `json:"mode"`
+	LastRefreshedAt *time.Time `json:"lastRefreshedAt,omitempty"`
+	LastError       *string    `json:"lastError,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+)
+
+type BackfillRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewBackfillRepo(pool *pgxpool.Pool) *BackfillRepo {
+	return &BackfillRepo{pool: pool}
+}
+
+func (r *BackfillRepo) Get(ctx context.Context, playerID, title, mode string) (*model.BackfillProgress, error) {
+	var p model.BackfillProgress
+	err := r.pool.QueryRow(ctx, `
+		SELECT player_id, title, mode, status, cursor_end, pages_fetched, matches_found,
+			last_error, started_at, updated_at, completed_at
+		FROM match_backfill_progress
+		WHERE player_id = $1 AND title = $2 AND mode = $3
+	`, playerID, title, mode).Scan(
+		&p.PlayerID, &p.Title, &p.Mode, &p.Status, &p.CursorEnd, &p.PagesFetched,
+		&p.MatchesFound, &p.LastError, &p.StartedAt, &p.UpdatedAt, &p.CompletedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save inserts or updates progress and refreshes its timestamps from the database.
+func (r *BackfillRepo) Save(ctx context.Context, p *model.BackfillProgress) error {
+	return r.pool.QueryRow(ctx, `
+		INSERT INTO match_backfill_progress (player_id, title, mode, status, cursor_end,
+			pages_fetched, matches_found, last_error, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (player_id, title, mode) DO UPDATE SET
+			status = EXCLUDED.status,
+			cursor_end = EXCLUDED.cursor_end,
+			pages_fetched = EXCLUDED.pages_fetched,
+			matches_found = EXCLUDED.matches_found,
+			last_error = EXCLUDED.last_error,
+			completed_at = EXCLUDED.completed_at,
+			updated_at = NOW()
+		RETURNING started_at, updated_at
+	`, p.PlayerID, p.Title, p.Mode, p.Status, p.CursorEnd, p.PagesFetched,
+		p.MatchesFound, p.LastError, p.CompletedAt).Scan(&p.StartedAt, &p.UpdatedAt)
+}
+
+// Reset deletes progress so the next backfill starts from the most recent match.
+func (r *BackfillRepo) Reset(ctx context.Context, playerID, title, mode string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM match_backfill_progress WHERE player_id = $1 AND title = $2 AND mode = $3
+	`, playerID, title, mode)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/grovecj/warzone-stats-tracker/internal/model"
@@ -17,40 +18,94 @@ func NewMatchRepo(pool *pgxpool.Pool) *MatchRepo {
 	return &MatchRepo{pool: pool}
 }
 
+// UpsertBatch stores a player's results for a batch of matches. The shared match row is
+// written by whichever player saves it first. Existing player rows are left alone, except
+// that a legacy raw_data (our own re-marshalled row) is replaced once the upstream
+// payload arrives.
 func (r *MatchRepo) UpsertBatch(ctx context.Context, playerID string, matches []model.Match) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	for _, m := range matches {
-		rawJSON, err := json.Marshal(m)
+		rawJSON := []byte(m.RawData)
+		if rawJSON == nil {
+			if rawJSON, err = json.Marshal(m); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO matches (match_id, title, mode, map_name, match_time)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (match_id, title) DO NOTHING
+		`, m.MatchID, m.Title, m.Mode, m.MapName, m.MatchTime)
 		if err != nil {
 			return err
 		}
-		_, err = r.pool.Exec(ctx, `
-			INSERT INTO matches (match_id, player_id, mode, map_name, placement, kills, deaths,
-				damage_dealt, damage_taken, gulag_result, match_time, raw_data)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-			ON CONFLICT (match_id, player_id) DO NOTHING
-		`, m.MatchID, playerID, m.Mode, m.MapName, m.Placement,
-			m.Kills, m.Deaths, m.DamageDealt, m.DamageTaken,
-			m.GulagResult, m.MatchTime, rawJSON)
+
+		// Only a payload carrying playerStats replaces one without; the typed stats follow
+		// it, and extended stats fill in only where the row has none yet
+		_, err = tx.Exec(ctx, `
+			INSERT INTO match_players (match_id, title, player_id, placement, kills, deaths,
+				damage_dealt, damage_taken, gulag_result, raw_data,
+				headshots, assists, score, time_played, distance_traveled, percent_time_moving,
+				revives, team_wipes, caches_opened, contracts, cash)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+				$11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			ON CONFLICT (match_id, title, player_id) DO UPDATE SET
+				raw_data = EXCLUDED.raw_data,
+				placement = EXCLUDED.placement,
+				kills = EXCLUDED.kills,
+				deaths = EXCLUDED.deaths,
+				damage_dealt = EXCLUDED.damage_dealt,
+				damage_taken = EXCLUDED.damage_taken,
+				gulag_result = EXCLUDED.gulag_result,
+				headshots = COALESCE(match_players.headshots, EXCLUDED.headshots),
+				assists = COALESCE(match_players.assists, EXCLUDED.assists),
+				score = COALESCE(match_players.score, EXCLUDED.score),
+				time_played = COALESCE(match_players.time_played, EXCLUDED.time_played),
+				distance_traveled = COALESCE(match_players.distance_traveled, EXCLUDED.distance_traveled),
+				percent_time_moving = COALESCE(match_players.percent_time_moving, EXCLUDED.percent_time_moving),
+				revives = COALESCE(match_players.revives, EXCLUDED.revives),
+				team_wipes = COALESCE(match_players.team_wipes, EXCLUDED.team_wipes),
+				caches_opened = COALESCE(match_players.caches_opened, EXCLUDED.caches_opened),
+				contracts = COALESCE(match_players.contracts, EXCLUDED.contracts),
+				cash = COALESCE(match_players.cash, EXCLUDED.cash)
+			WHERE EXCLUDED.raw_data ? 'playerStats'
+				AND (match_players.raw_data IS NULL OR NOT match_players.raw_data ? 'playerStats')
+		`, m.MatchID, m.Title, playerID, m.Placement, m.Kills, m.Deaths,
+			m.DamageDealt, m.DamageTaken, m.GulagResult, rawJSON,
+			m.Headshots, m.Assists, m.Score, m.TimePlayed, m.DistanceTraveled, m.PercentTimeMoving,
+			m.Revives, m.TeamWipes, m.CachesOpened, m.Contracts, m.Cash)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+
+	return tx.Commit(ctx)
 }
 
-func (r *MatchRepo) GetByPlayerID(ctx context.Context, playerID string, limit, offset int) ([]model.Match, error) {
+func (r *MatchRepo) GetByPlayerID(ctx context.Context, playerID, title string, limit, offset int) ([]model.Match, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, match_id, player_id, mode, map_name, placement, kills, deaths,
-			damage_dealt, damage_taken, gulag_result, match_time, created_at
-		FROM matches
-		WHERE player_id = $1
-		ORDER BY match_time DESC NULLS LAST
-		LIMIT $2 OFFSET $3
-	`, playerID, limit, offset)
+		SELECT mp.id, m.match_id, mp.player_id, m.title, m.mode, m.map_name, mp.placement,
+			mp.kills, mp.deaths, mp.damage_dealt, mp.damage_taken, mp.gulag_result,
+			m.match_time, mp.created_at,
+			mp.headshots, mp.assists, mp.score, mp.time_played, mp.distance_traveled,
+			mp.percent_time_moving, mp.revives, mp.team_wipes, mp.caches_opened,
+			mp.contracts, mp.cash
+		FROM match_players mp
+		JOIN matches m ON m.match_id = mp.match_id AND m.title = mp.title
+		WHERE mp.player_id = $1 AND mp.title = $2
+		ORDER BY m.match_time DESC NULLS LAST
+		LIMIT $3 OFFSET $4
+	`, playerID, title, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -59,9 +114,11 @@ func (r *MatchRepo) GetByPlayerID(ctx context.Context, playerID string, limit, o
 	var matches []model.Match
 	for rows.Next() {
 		var m model.Match
-		if err := rows.Scan(&m.ID, &m.MatchID, &m.PlayerID, &m.Mode, &m.MapName,
+		if err := rows.Scan(&m.ID, &m.MatchID, &m.PlayerID, &m.Title, &m.Mode, &m.MapName,
 			&m.Placement, &m.Kills, &m.Deaths, &m.DamageDealt, &m.DamageTaken,
-			&m.GulagResult, &m.MatchTime, &m.CreatedAt); err != nil {
+			&m.GulagResult, &m.MatchTime, &m.CreatedAt,
+			&m.Headshots, &m.Assists, &m.Score, &m.TimePlayed, &m.DistanceTraveled, &m.PercentTimeMoving,
+			&m.Revives, &m.TeamWipes, &m.CachesOpened, &m.Contracts, &m.Cash); err != nil {
 			return nil, err
 		}
 		matches = append(matches, m)
@@ -72,8 +129,191 @@ func (r *MatchRepo) GetByPlayerID(ctx context.Context, playerID string, limit, o
 	return matches, nil
 }
 
-func (r *MatchRepo) CountByPlayerID(ctx context.Context, playerID string) (int, error) {
+// GetPlayersInMatch returns every stored player with results in a match, ordered by gamertag.
+func (r *MatchRepo) GetPlayersInMatch(ctx context.Context, matchID, title string) ([]model.Player, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id, p.platform, p.gamertag, p.activision_id, p.last_fetched_at, p.created_at, p.updated_at
+		FROM match_players mp
+		JOIN players p ON p.id = mp.player_id
+		WHERE mp.match_id = $1 AND mp.title = $2
+		ORDER BY p.gamertag
+	`, matchID, title)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []model.Player
+	for rows.Next() {
+		var p model.Player
+		if err := rows.Scan(&p.ID, &p.Platform, &p.Gamertag, &p.ActivisionID,
+			&p.LastFetchedAt, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// ListRawMatches returns up to limit player match results holding an upstream payload,
+// ordered by row ID and starting after afterID ("" for the first page).
+func (r *MatchRepo) ListRawMatches(ctx context.Context, afterID string, limit int) ([]model.RawPayload, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, player_id, title, raw_data FROM match_players
+		WHERE raw_data ? 'playerStats' AND ($1 = '' OR id > $1::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payloads []model.RawPayload
+	for rows.Next() {
+		var p model.RawPayload
+		if err := rows.Scan(&p.ID, &p.PlayerID, &p.Title, &p.Data); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, rows.Err()
+}
+
+// UpdateDerived rewrites the typed columns of a player's match result and of the shared
+// match it belongs to, reporting whether any of them changed.
+func (r *MatchRepo) UpdateDerived(ctx context.Context, id string, m model.Match) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var matchID, title string
+	err = tx.QueryRow(ctx, `SELECT match_id, title FROM match_players WHERE id = $1`, id).Scan(&matchID, &title)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	playerTag, err := tx.Exec(ctx, `
+		UPDATE match_players SET placement = $2, kills = $3, deaths = $4, damage_dealt = $5,
+			damage_taken = $6, gulag_result = $7, headshots = $8, assists = $9, score = $10,
+			time_played = $11, distance_traveled = $12, percent_time_moving = $13, revives = $14,
+			team_wipes = $15, caches_opened = $16, contracts = $17, cash = $18
+		WHERE id = $1
+			AND (placement, kills, deaths, damage_dealt, damage_taken, gulag_result,
+				headshots, assists, score, time_played, distance_traveled, percent_time_moving,
+				revives, team_wipes, caches_opened, contracts, cash)
+				IS DISTINCT FROM ($2, $3, $4, $5, $6, $7, $8, $9, $10,
+				$11, $12, $13, $14, $15, $16, $17, $18)
+	`, id, m.Placement, m.Kills, m.Deaths, m.DamageDealt, m.DamageTaken, m.GulagResult,
+		m.Headshots, m.Assists, m.Score, m.TimePlayed, m.DistanceTraveled, m.PercentTimeMoving,
+		m.Revives, m.TeamWipes, m.CachesOpened, m.Contracts, m.Cash)
+	if err != nil {
+		return false, err
+	}
+
+	matchTag, err := tx.Exec(ctx, `
+		UPDATE matches SET mode = $3, map_name = $4, match_time = $5
+		WHERE match_id = $1 AND title = $2
+			AND (mode, map_name, match_time) IS DISTINCT FROM ($3, $4, $5)
+	`, matchID, title, m.Mode, m.MapName, m.MatchTime)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return playerTag.RowsAffected() > 0 || matchTag.RowsAffected() > 0, nil
+}
+
+func (r *MatchRepo) CountByPlayerID(ctx context.Context, playerID, title string) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM matches WHERE player_id = $1`, playerID).Scan(&count)
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM match_players WHERE player_id = $1 AND title = $2`, playerID, title).Scan(&count)
 	return count, err
 }
+
+// GetDetails returns a stored full match lobby, or nil if it hasn't been fetched yet.
+func (r *MatchRepo) GetDetails(ctx context.Context, matchID, title string) (*model.MatchDetails, error) {
+	var d model.MatchDetails
+	err := r.pool.QueryRow(ctx, `
+		SELECT match_id, title, COALESCE(mode, ''), COALESCE(map_name, ''), COALESCE(duration, 0),
+			match_time, details_fetched_at
+		FROM matches WHERE match_id = $1 AND title = $2 AND details_fetched_at IS NOT NULL
+	`, matchID, title).Scan(&d.MatchID, &d.Title, &d.Mode, &d.MapName, &d.Duration, &d.MatchTime, &d.FetchedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT username, COALESCE(clantag, ''), COALESCE(uno_id, ''), COALESCE(team, ''),
+			placement, kills, deaths, kd_ratio, damage_dealt, damage_taken
+		FROM match_participants
+		WHERE match_id = $1 AND title = $2
+		ORDER BY placement, team, kills DESC
+	`, matchID, title)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p model.MatchParticipant
+		if err := rows.Scan(&p.Username, &p.Clantag, &p.UnoID, &p.Team, &p.Placement,
+			&p.Kills, &p.Deaths, &p.KDRatio, &p.DamageDealt, &p.DamageTaken); err != nil {
+			return nil, err
+		}
+		d.Participants = append(d.Participants, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// SaveDetails stores a full match lobby and its participants in one transaction. The lobby
+// lives on the shared match row, which is created if no stored player has the match yet.
+func (r *MatchRepo) SaveDetails(ctx context.Context, d *model.MatchDetails) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO matches (match_id, title, mode, map_name, match_time, duration, details_fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (match_id, title) DO UPDATE
+			SET duration = EXCLUDED.duration, details_fetched_at = EXCLUDED.details_fetched_at
+		RETURNING details_fetched_at
+	`, d.MatchID, d.Title, d.Mode, d.MapName, d.MatchTime, d.Duration).Scan(&d.FetchedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM match_participants WHERE match_id = $1 AND title = $2
+	`, d.MatchID, d.Title); err != nil {
+		return err
+	}
+	for _, p := range d.Participants {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO match_participants (match_id, title, username, clantag, uno_id, team,
+				placement, kills, deaths, kd_ratio, damage_dealt, damage_taken)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		`, d.MatchID, d.Title, p.Username, p.Clantag, p.UnoID, p.Team,
+			p.Placement, p.Kills, p.Deaths, p.KDRatio, p.DamageDealt, p.DamageTaken)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
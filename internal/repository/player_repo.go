@@ -72,21 +72,61 @@ func (r *PlayerRepo) GetByID(ctx context.Context, id string) (*model.Player, err
 	return &p, nil
 }
 
-func (r *PlayerRepo) SaveStatsSnapshot(ctx context.Context, playerID, mode string, statsData any) error {
+// SaveStatsSnapshot stores derived stats along with the upstream payload they came from.
+// rawData may be nil when the payload isn't available.
+func (r *PlayerRepo) SaveStatsSnapshot(ctx context.Context, playerID, title, mode string, statsData any, rawData []byte) error {
 	_, err := r.pool.Exec(ctx, `
-		INSERT INTO player_stats (player_id, mode, stats_data) VALUES ($1, $2, $3)
-	`, playerID, mode, statsData)
+		INSERT INTO player_stats (player_id, title, mode, stats_data, raw_data) VALUES ($1, $2, $3, $4, $5)
+	`, playerID, title, mode, statsData, rawData)
 	return err
 }
 
-func (r *PlayerRepo) GetLatestStats(ctx context.Context, playerID, mode string) (any, *time.Time, error) {
+// ListRawStats returns up to limit stats snapshots holding an upstream payload, ordered by
+// row ID and starting after afterID ("" for the first page).
+func (r *PlayerRepo) ListRawStats(ctx context.Context, afterID string, limit int) ([]model.RawPayload, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT s.id, s.player_id, p.platform, p.gamertag, s.title, s.raw_data
+		FROM player_stats s
+		JOIN players p ON p.id = s.player_id
+		WHERE s.raw_data IS NOT NULL AND ($1 = '' OR s.id > $1::uuid)
+		ORDER BY s.id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payloads []model.RawPayload
+	for rows.Next() {
+		var p model.RawPayload
+		if err := rows.Scan(&p.ID, &p.PlayerID, &p.Platform, &p.Gamertag, &p.Title, &p.Data); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, rows.Err()
+}
+
+// UpdateStatsData replaces a snapshot's derived stats, reporting whether they changed.
+func (r *PlayerRepo) UpdateStatsData(ctx context.Context, id string, statsData []byte) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE player_stats SET stats_data = $2 WHERE id = $1 AND stats_data IS DISTINCT FROM $2::jsonb
+	`, id, statsData)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PlayerRepo) GetLatestStats(ctx context.Context, playerID, title, mode string) (any, *time.Time, error) {
 	var statsData any
 	var fetchedAt time.Time
 	err := r.pool.QueryRow(ctx, `
 		SELECT stats_data, fetched_at FROM player_stats
-		WHERE player_id = $1 AND mode = $2
+		WHERE player_id = $1 AND title = $2 AND mode = $3
 		ORDER BY fetched_at DESC LIMIT 1
-	`, playerID, mode).Scan(&statsData, &fetchedAt)
+	`, playerID, title, mode).Scan(&statsData, &fetchedAt)
 	if err == pgx.ErrNoRows {
 		return nil, nil, nil
 	}
@@ -95,3 +135,39 @@ func (r *PlayerRepo) GetLatestStats(ctx context.Context, playerID, mode string)
 	}
 	return statsData, &fetchedAt, nil
 }
+
+// GetStatsHistory returns the last snapshot in each time bucket (hour, day, week or month)
+// between from and to, oldest first. Snapshots hold lifetime totals, so the last one in a
+// bucket represents the player's standing at the end of that bucket.
+func (r *PlayerRepo) GetStatsHistory(ctx context.Context, playerID, title, mode, bucket string, from, to time.Time, limit int) ([]model.PlayerStatsSnapshot, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, player_id, title, mode, stats_data, fetched_at FROM (
+			SELECT DISTINCT ON (date_trunc($4, fetched_at AT TIME ZONE 'UTC'))
+				id, player_id, title, mode, stats_data, fetched_at
+			FROM player_stats
+			WHERE player_id = $1 AND title = $2 AND mode = $3 AND fetched_at >= $5 AND fetched_at < $6
+			ORDER BY date_trunc($4, fetched_at AT TIME ZONE 'UTC'), fetched_at DESC
+		) buckets
+		ORDER BY fetched_at
+		LIMIT $7
+	`, playerID, title, mode, bucket, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []model.PlayerStatsSnapshot
+	for rows.Next() {
+		var s model.PlayerStatsSnapshot
+		var data []byte
+		if err := rows.Scan(&s.ID, &s.PlayerID, &s.Title, &s.Mode, &data, &s.FetchedAt); err != nil {
+			return nil, err
+		}
+		s.StatsData = data
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
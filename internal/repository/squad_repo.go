@@ -80,12 +80,44 @@ func (r *SquadRepo) Delete(ctx context.Context, id string) error {
 	return err
 }
 
-func (r *SquadRepo) AddMember(ctx context.Context, squadID, playerID string) error {
-	_, err := r.pool.Exec(ctx, `
+// AddMember adds a player to a squad holding fewer than maxMembers members. The squad row
+// is locked so concurrent adds can't overshoot the cap. It reports false, without error,
+// when the squad is full or no longer exists; re-adding an existing member reports true.
+func (r *SquadRepo) AddMember(ctx context.Context, squadID, playerID string, maxMembers int) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var count int
+	var member bool
+	err = tx.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM squad_members WHERE squad_id = s.id),
+			EXISTS (SELECT 1 FROM squad_members WHERE squad_id = s.id AND player_id = $2)
+		FROM squads s WHERE s.id = $1
+		FOR UPDATE OF s
+	`, squadID, playerID).Scan(&count, &member)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if member {
+		return true, nil
+	}
+	if count >= maxMembers {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `
 		INSERT INTO squad_members (squad_id, player_id) VALUES ($1, $2)
-		ON CONFLICT DO NOTHING
-	`, squadID, playerID)
-	return err
+	`, squadID, playerID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
 }
 
 func (r *SquadRepo) RemoveMember(ctx context.Context, squadID, playerID string) error {
@@ -94,11 +126,3 @@ func (r *SquadRepo) RemoveMember(ctx context.Context, squadID, playerID string)
 	`, squadID, playerID)
 	return err
 }
-
-func (r *SquadRepo) MemberCount(ctx context.Context, squadID string) (int, error) {
-	var count int
-	err := r.pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM squad_members WHERE squad_id = $1
-	`, squadID).Scan(&count)
-	return count, err
-}
@@ -1,3 +1,4 @@
 package repository
 
-// Stats snapshot storage is handled by PlayerRepo.SaveStatsSnapshot and PlayerRepo.GetLatestStats.
+// Stats snapshot storage is handled by PlayerRepo.SaveStatsSnapshot, PlayerRepo.GetLatestStats
+// and PlayerRepo.GetStatsHistory.
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TokenChannel is the Postgres NOTIFY channel used to broadcast SSO token changes.
+// Each notification's payload is the name of the token that changed.
+const TokenChannel = "sso_tokens"
+
+// EncryptedToken is an SSO token as stored at rest.
+type EncryptedToken struct {
+	Name       string
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+type TokenRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTokenRepo(pool *pgxpool.Pool) *TokenRepo {
+	return &TokenRepo{pool: pool}
+}
+
+// Save upserts a token and notifies listeners in the same transaction.
+func (r *TokenRepo) Save(ctx context.Context, t EncryptedToken) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO sso_tokens (name, nonce, ciphertext) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET nonce = EXCLUDED.nonce,
+			ciphertext = EXCLUDED.ciphertext, updated_at = NOW()
+	`, t.Name, t.Nonce, t.Ciphertext)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, TokenChannel, t.Name); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete removes a token and notifies listeners in the same transaction.
+func (r *TokenRepo) Delete(ctx context.Context, name string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM sso_tokens WHERE name = $1`, name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, TokenChannel, name); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TokenRepo) Get(ctx context.Context, name string) (*EncryptedToken, error) {
+	var t EncryptedToken
+	err := r.pool.QueryRow(ctx, `
+		SELECT name, nonce, ciphertext FROM sso_tokens WHERE name = $1
+	`, name).Scan(&t.Name, &t.Nonce, &t.Ciphertext)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TokenRepo) List(ctx context.Context) ([]EncryptedToken, error) {
+	rows, err := r.pool.Query(ctx, `SELECT name, nonce, ciphertext FROM sso_tokens ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []EncryptedToken
+	for rows.Next() {
+		var t EncryptedToken
+		if err := rows.Scan(&t.Name, &t.Nonce, &t.Ciphertext); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Listen holds a dedicated connection on TokenChannel and calls onChange with the
+// name of each changed token until ctx is cancelled or the connection fails.
+func (r *TokenRepo) Listen(ctx context.Context, onChange func(name string)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+TokenChannel); err != nil {
+		return err
+	}
+	// Don't hand a still-listening connection back to the pool
+	defer conn.Exec(context.Background(), "UNLISTEN *")
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onChange(n.Payload)
+	}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+)
+
+type TrackedRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTrackedRepo(pool *pgxpool.Pool) *TrackedRepo {
+	return &TrackedRepo{pool: pool}
+}
+
+func (r *TrackedRepo) Add(ctx context.Context, playerID, title, mode string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO tracked_players (player_id, title, mode) VALUES ($1, $2, $3)
+		ON CONFLICT (player_id) DO UPDATE SET title = EXCLUDED.title, mode = EXCLUDED.mode
+	`, playerID, title, mode)
+	return err
+}
+
+// Remove stops tracking a player and reports whether they were tracked.
+func (r *TrackedRepo) Remove(ctx context.Context, playerID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tracked_players WHERE player_id = $1`, playerID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// List returns all tracked players, least recently refreshed first.
+func (r *TrackedRepo) List(ctx context.Context) ([]model.TrackedPlayer, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT t.player_id, p.platform, p.gamertag, t.title, t.mode,
+			t.last_refreshed_at, t.last_error, t.created_at
+		FROM tracked_players t
+		JOIN players p ON p.id = t.player_id
+		ORDER BY t.last_refreshed_at NULLS FIRST, t.created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tracked []model.TrackedPlayer
+	for rows.Next() {
+		var t model.TrackedPlayer
+		if err := rows.Scan(&t.PlayerID, &t.Platform, &t.Gamertag, &t.Title, &t.Mode,
+			&t.LastRefreshedAt, &t.LastError, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		tracked = append(tracked, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tracked, nil
+}
+
+// MarkRefreshed records the outcome of a refresh. A nil refreshErr clears any previous error.
+func (r *TrackedRepo) MarkRefreshed(ctx context.Context, playerID string, refreshErr error) error {
+	var lastError *string
+	if refreshErr != nil {
+		msg := refreshErr.Error()
+		lastError = &msg
+	}
+	_, err := r.pool.Exec(ctx, `
+		UPDATE tracked_players SET last_refreshed_at = NOW(), last_error = $2 WHERE player_id = $1
+	`, playerID, lastError)
+	return err
+}
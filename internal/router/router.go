@@ -15,10 +15,16 @@ import (
 
 // Deps holds dependencies injected into the router.
 type Deps struct {
-	AdminHandler  *handler.AdminHandler
-	PlayerHandler *handler.PlayerHandler
-	MatchHandler  *handler.MatchHandler
-	AdminAPIKey   string
+	HealthHandler    *handler.HealthHandler
+	AdminHandler     *handler.AdminHandler
+	PlayerHandler    *handler.PlayerHandler
+	MatchHandler     *handler.MatchHandler
+	SquadHandler     *handler.SquadHandler
+	TrackingHandler  *handler.TrackingHandler
+	BackfillHandler  *handler.BackfillHandler
+	CacheHandler     *handler.CacheHandler
+	ReprocessHandler *handler.ReprocessHandler
+	AdminAPIKey      string
 }
 
 func New(allowedOrigins []string, staticFS fs.FS, deps Deps) http.Handler {
@@ -39,16 +45,25 @@ func New(allowedOrigins []string, staticFS fs.FS, deps Deps) http.Handler {
 
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Get("/health", handler.Health)
+		if deps.HealthHandler != nil {
+			r.Get("/health", deps.HealthHandler.Health)
+		} else {
+			r.Get("/health", handler.Health)
+		}
+		r.Get("/titles", handler.Titles)
 
 		// Player routes
 		r.Route("/players", func(r chi.Router) {
 			if deps.PlayerHandler != nil {
 				r.Get("/search", deps.PlayerHandler.SearchPlayer)
 				r.Get("/{platform}/{gamertag}/stats", deps.PlayerHandler.GetStats)
+				r.Get("/{platform}/{gamertag}/stats/history", deps.PlayerHandler.GetStatsHistory)
+				r.Get("/{platform}/{gamertag}/weapons", deps.PlayerHandler.GetWeapons)
 			} else {
 				r.Get("/search", handler.NotImplemented)
 				r.Get("/{platform}/{gamertag}/stats", handler.NotImplemented)
+				r.Get("/{platform}/{gamertag}/stats/history", handler.NotImplemented)
+				r.Get("/{platform}/{gamertag}/weapons", handler.NotImplemented)
 			}
 			if deps.MatchHandler != nil {
 				r.Get("/{platform}/{gamertag}/matches", deps.MatchHandler.GetMatches)
@@ -57,26 +72,66 @@ func New(allowedOrigins []string, staticFS fs.FS, deps Deps) http.Handler {
 			}
 		})
 
-		// Comparison routes (issue #14)
-		r.Get("/compare", handler.NotImplemented)
+		// Match routes
+		if deps.MatchHandler != nil {
+			r.Get("/matches/{matchID}", deps.MatchHandler.GetMatchDetails)
+		} else {
+			r.Get("/matches/{matchID}", handler.NotImplemented)
+		}
+
+		// Comparison routes
+		if deps.PlayerHandler != nil {
+			r.Get("/compare", deps.PlayerHandler.ComparePlayers)
+		} else {
+			r.Get("/compare", handler.NotImplemented)
+		}
 
 		// Admin routes (protected by ADMIN_API_KEY)
 		r.Route("/admin", func(r chi.Router) {
 			r.Use(middleware.AdminAuth(deps.AdminAPIKey))
 			if deps.AdminHandler != nil {
 				r.Post("/token", deps.AdminHandler.UpdateToken)
+				r.Get("/tokens", deps.AdminHandler.ListTokens)
+				r.Post("/tokens", deps.AdminHandler.AddToken)
+				r.Delete("/tokens/{name}", deps.AdminHandler.RemoveToken)
+			}
+			if deps.TrackingHandler != nil {
+				r.Get("/tracked", deps.TrackingHandler.ListTracked)
+				r.Post("/tracked", deps.TrackingHandler.Follow)
+				r.Delete("/tracked/{playerID}", deps.TrackingHandler.Unfollow)
+			}
+			if deps.BackfillHandler != nil {
+				r.Post("/backfill", deps.BackfillHandler.StartBackfill)
+				r.Get("/backfill/{platform}/{gamertag}", deps.BackfillHandler.GetBackfill)
+			}
+			if deps.CacheHandler != nil {
+				r.Delete("/cache/negative/{platform}/{gamertag}", deps.CacheHandler.ClearNegative)
+			}
+			if deps.ReprocessHandler != nil {
+				r.Post("/reprocess", deps.ReprocessHandler.StartReprocess)
+				r.Get("/reprocess", deps.ReprocessHandler.GetReprocess)
 			}
 		})
 
-		// Squad routes (issue #16)
+		// Squad routes
 		r.Route("/squads", func(r chi.Router) {
-			r.Post("/", handler.NotImplemented)
-			r.Get("/{squadID}", handler.NotImplemented)
-			r.Put("/{squadID}", handler.NotImplemented)
-			r.Delete("/{squadID}", handler.NotImplemented)
-			r.Post("/{squadID}/members", handler.NotImplemented)
-			r.Delete("/{squadID}/members/{playerID}", handler.NotImplemented)
-			r.Get("/{squadID}/stats", handler.NotImplemented)
+			if deps.SquadHandler != nil {
+				r.Post("/", deps.SquadHandler.CreateSquad)
+				r.Get("/{squadID}", deps.SquadHandler.GetSquad)
+				r.Put("/{squadID}", deps.SquadHandler.UpdateSquad)
+				r.Delete("/{squadID}", deps.SquadHandler.DeleteSquad)
+				r.Post("/{squadID}/members", deps.SquadHandler.AddMember)
+				r.Delete("/{squadID}/members/{playerID}", deps.SquadHandler.RemoveMember)
+				r.Get("/{squadID}/stats", deps.SquadHandler.GetSquadStats)
+			} else {
+				r.Post("/", handler.NotImplemented)
+				r.Get("/{squadID}", handler.NotImplemented)
+				r.Put("/{squadID}", handler.NotImplemented)
+				r.Delete("/{squadID}", handler.NotImplemented)
+				r.Post("/{squadID}/members", handler.NotImplemented)
+				r.Delete("/{squadID}/members/{playerID}", handler.NotImplemented)
+				r.Get("/{squadID}/stats", handler.NotImplemented)
+			}
 		})
 	})
 
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/repository"
+)
+
+const (
+	// backfillPageDelay spaces out historical page fetches to protect the upstream quota.
+	backfillPageDelay = 3 * time.Second
+
+	// maxBackfillPages bounds a single run; a paused backfill resumes where it left off.
+	maxBackfillPages = 500
+
+	backfillSaveTimeout = 5 * time.Second
+)
+
+var (
+	ErrBackfillRunning  = errors.New("backfill already running")
+	ErrBackfillNotFound = errors.New("no backfill recorded")
+)
+
+// BackfillService walks a player's match history backwards in time and persists every page.
+// Progress is stored per player, title and mode so interrupted runs resume from their cursor.
+type BackfillService struct {
+	codClient    codclient.CodClient
+	matchRepo    *repository.MatchRepo
+	playerRepo   *repository.PlayerRepo
+	backfillRepo *repository.BackfillRepo
+
+	mu      sync.Mutex
+	running map[string]bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewBackfillService creates a new BackfillService.
+func NewBackfillService(codClient codclient.CodClient, matchRepo *repository.MatchRepo, playerRepo *repository.PlayerRepo, backfillRepo *repository.BackfillRepo) *BackfillService {
+	ctx, cancel := context.WithCancel(codclient.WithPriority(context.Background(), codclient.PriorityBackground))
+	return &BackfillService{
+		codClient:    codClient,
+		matchRepo:    matchRepo,
+		playerRepo:   playerRepo,
+		backfillRepo: backfillRepo,
+		running:      make(map[string]bool),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start launches a background backfill for a known player. With restart set, any stored
+// progress is discarded and the walk begins again from the most recent match.
+func (s *BackfillService) Start(ctx context.Context, platform, gamertag, title, mode string, restart bool) (*model.BackfillProgress, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	title = t.ID
+
+	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, codclient.ErrPlayerNotFound
+	}
+
+	key := player.ID + ":" + title + ":" + mode
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[key] {
+		return nil, ErrBackfillRunning
+	}
+
+	if restart {
+		if err := s.backfillRepo.Reset(ctx, player.ID, title, mode); err != nil {
+			return nil, err
+		}
+	}
+
+	progress, err := s.backfillRepo.Get(ctx, player.ID, title, mode)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		progress = &model.BackfillProgress{PlayerID: player.ID, Title: title, Mode: mode}
+	}
+	if progress.Status == model.BackfillComplete {
+		return progress, nil
+	}
+
+	progress.Status = model.BackfillRunning
+	progress.LastError = nil
+	if err := s.backfillRepo.Save(ctx, progress); err != nil {
+		return nil, err
+	}
+
+	s.running[key] = true
+	s.wg.Add(1)
+	job := *progress
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, key)
+			s.mu.Unlock()
+		}()
+		s.run(&job, platform, gamertag)
+	}()
+
+	return progress, nil
+}
+
+// Status returns the stored backfill progress for a player.
+func (s *BackfillService) Status(ctx context.Context, platform, gamertag, title, mode string) (*model.BackfillProgress, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	title = t.ID
+
+	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, codclient.ErrPlayerNotFound
+	}
+
+	progress, err := s.backfillRepo.Get(ctx, player.ID, title, mode)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		return nil, ErrBackfillNotFound
+	}
+	return progress, nil
+}
+
+// Shutdown stops running backfills, leaving them paused, and waits for them to save progress.
+func (s *BackfillService) Shutdown(ctx context.Context) {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("backfill jobs did not stop before shutdown deadline")
+	}
+}
+
+func (s *BackfillService) run(p *model.BackfillProgress, platform, gamertag string) {
+	log := slog.With("player_id", p.PlayerID, "title", p.Title, "mode", p.Mode)
+	log.Info("match backfill started", "cursor_end", p.CursorEnd)
+
+	for page := 0; ; page++ {
+		if page >= maxBackfillPages {
+			s.pause(p, nil)
+			log.Info("match backfill paused at page limit", "pages_fetched", p.PagesFetched)
+			return
+		}
+		if page > 0 {
+			select {
+			case <-s.ctx.Done():
+				s.pause(p, s.ctx.Err())
+				return
+			case <-time.After(backfillPageDelay):
+			}
+		}
+
+		var end time.Time
+		if p.CursorEnd != nil {
+			end = *p.CursorEnd
+		}
+		matches, err := s.codClient.GetMatchesRange(s.ctx, platform, gamertag, p.Title, p.Mode, time.Time{}, end)
+		if err != nil {
+			log.Warn("match backfill page failed", "error", err)
+			s.pause(p, err)
+			return
+		}
+		if len(matches) == 0 {
+			s.complete(p)
+			log.Info("match backfill complete", "matches_found", p.MatchesFound)
+			return
+		}
+
+		if err := s.matchRepo.UpsertBatch(s.ctx, p.PlayerID, toModelMatches(p.PlayerID, p.Title, matches)); err != nil {
+			log.Warn("failed to persist backfilled matches", "error", err)
+			s.pause(p, fmt.Errorf("persisting matches: %w", err))
+			return
+		}
+
+		oldest := matches[0].MatchTime
+		for _, m := range matches[1:] {
+			if m.MatchTime.Before(oldest) {
+				oldest = m.MatchTime
+			}
+		}
+
+		p.PagesFetched++
+		p.MatchesFound += len(matches)
+
+		// A page that doesn't reach before the cursor would repeat forever
+		if p.CursorEnd != nil && !oldest.Before(*p.CursorEnd) {
+			s.complete(p)
+			log.Info("match backfill complete", "matches_found", p.MatchesFound)
+			return
+		}
+
+		next := oldest.Add(-time.Millisecond)
+		p.CursorEnd = &next
+		s.save(p)
+	}
+}
+
+func (s *BackfillService) pause(p *model.BackfillProgress, cause error) {
+	p.Status = model.BackfillPaused
+	p.LastError = nil
+	if cause != nil {
+		msg := cause.Error()
+		p.LastError = &msg
+	}
+	s.save(p)
+}
+
+func (s *BackfillService) complete(p *model.BackfillProgress) {
+	now := time.Now()
+	p.Status = model.BackfillComplete
+	p.LastError = nil
+	p.CompletedAt = &now
+	s.save(p)
+}
+
+// save persists progress on its own deadline so it still lands after shutdown cancels the job.
+func (s *BackfillService) save(p *model.BackfillProgress) {
+	ctx, cancel := context.WithTimeout(context.Background(), backfillSaveTimeout)
+	defer cancel()
+
+	if err := s.backfillRepo.Save(ctx, p); err != nil {
+		slog.Error("failed to save backfill progress", "player_id", p.PlayerID, "error", err)
+	}
+}
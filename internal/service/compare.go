@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+// MaxComparePlayers caps how many players can be compared in one request.
+const MaxComparePlayers = 6
+
+// PlayerRef identifies a player by platform and gamertag.
+type PlayerRef struct {
+	Platform string `json:"platform"`
+	Gamertag string `json:"gamertag"`
+}
+
+// ComparedPlayer holds one player's stats within a comparison. Stats is nil when they couldn't be loaded.
+type ComparedPlayer struct {
+	Platform string                 `json:"platform"`
+	Gamertag string                 `json:"gamertag"`
+	Stats    *codclient.PlayerStats `json:"stats,omitempty"`
+}
+
+// MetricComparison lists one stat for every compared player, in request order.
+// Values are nil for players whose stats couldn't be loaded. Leader is the index
+// of the best player, or nil when there's a tie or no data.
+type MetricComparison struct {
+	Metric        string     `json:"metric"`
+	LowerIsBetter bool       `json:"lowerIsBetter,omitempty"`
+	Values        []*float64 `json:"values"`
+	Leader        *int       `json:"leader"`
+}
+
+// CompareResult contains a side-by-side comparison of several players.
+type CompareResult struct {
+	Players     []ComparedPlayer   `json:"players"`
+	Metrics     []MetricComparison `json:"metrics"`
+	CommonModes []string           `json:"commonModes"`
+}
+
+// comparedMetric describes how to extract a comparable value from PlayerStats.
+type comparedMetric struct {
+	name          string
+	lowerIsBetter bool
+	value         func(*codclient.PlayerStats) float64
+}
+
+var comparedMetrics = []comparedMetric{
+	{name: "kdRatio", value: func(s *codclient.PlayerStats) float64 { return s.KDRatio }},
+	{name: "kills", value: func(s *codclient.PlayerStats) float64 { return float64(s.Kills) }},
+	{name: "deaths", lowerIsBetter: true, value: func(s *codclient.PlayerStats) float64 { return float64(s.Deaths) }},
+	{name: "wins", value: func(s *codclient.PlayerStats) float64 { return float64(s.Wins) }},
+	{name: "winPct", value: func(s *codclient.PlayerStats) float64 { return s.WinPct }},
+	{name: "scorePerMin", value: func(s *codclient.PlayerStats) float64 { return s.ScorePerMin }},
+	{name: "damagePerMatch", value: func(s *codclient.PlayerStats) float64 { return ratio(s.DamageDone, s.MatchesPlayed) }},
+	{name: "killsPerMatch", value: func(s *codclient.PlayerStats) float64 { return ratio(s.Kills, s.MatchesPlayed) }},
+	{name: "headshots", value: func(s *codclient.PlayerStats) float64 { return float64(s.Headshots) }},
+	{name: "assists", value: func(s *codclient.PlayerStats) float64 { return float64(s.Assists) }},
+	{name: "damageDone", value: func(s *codclient.PlayerStats) float64 { return float64(s.DamageDone) }},
+	{name: "matchesPlayed", value: func(s *codclient.PlayerStats) float64 { return float64(s.MatchesPlayed) }},
+	{name: "topFive", value: func(s *codclient.PlayerStats) float64 { return float64(s.TopFive) }},
+	{name: "topTen", value: func(s *codclient.PlayerStats) float64 { return float64(s.TopTen) }},
+	{name: "topTwentyFive", value: func(s *codclient.PlayerStats) float64 { return float64(s.TopTwentyFive) }},
+	{name: "level", value: func(s *codclient.PlayerStats) float64 { return float64(s.Level) }},
+}
+
+// ComparePlayers fetches stats for every player in parallel and lines them up metric by metric.
+// Players whose stats can't be loaded don't fail the request; their errors are returned
+// alongside the result, indexed like Players, with nil for players that loaded.
+// An unsupported title or mode fails the whole request.
+func (s *PlayerService) ComparePlayers(ctx context.Context, refs []PlayerRef, title, mode string) (*CompareResult, []error, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	title = t.ID
+
+	players := make([]ComparedPlayer, len(refs))
+	errs := make([]error, len(refs))
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		players[i] = ComparedPlayer{Platform: ref.Platform, Gamertag: ref.Gamertag}
+
+		wg.Add(1)
+		go func(p *ComparedPlayer, errp *error) {
+			defer wg.Done()
+
+			stats, err := s.GetPlayerStats(ctx, p.Platform, p.Gamertag, title, mode)
+			if err != nil {
+				slog.Warn("failed to fetch stats for comparison",
+					"platform", p.Platform, "gamertag", p.Gamertag, "error", err)
+				*errp = err
+				return
+			}
+			p.Stats = stats
+		}(&players[i], &errs[i])
+	}
+	wg.Wait()
+
+	metrics := make([]MetricComparison, 0, len(comparedMetrics))
+	for _, cm := range comparedMetrics {
+		mc := MetricComparison{
+			Metric:        cm.name,
+			LowerIsBetter: cm.lowerIsBetter,
+			Values:        make([]*float64, len(players)),
+		}
+		best, tied := -1, false
+		for i, p := range players {
+			if p.Stats == nil {
+				continue
+			}
+			v := cm.value(p.Stats)
+			mc.Values[i] = &v
+
+			switch {
+			case best < 0:
+				best = i
+			case v == *mc.Values[best]:
+				tied = true
+			case (v > *mc.Values[best]) != cm.lowerIsBetter:
+				best, tied = i, false
+			}
+		}
+		if best >= 0 && !tied {
+			mc.Leader = &best
+		}
+		metrics = append(metrics, mc)
+	}
+
+	return &CompareResult{
+		Players:     players,
+		Metrics:     metrics,
+		CommonModes: commonModes(players),
+	}, errs, nil
+}
+
+// commonModes returns the sorted mode names present in every loaded player's ModeBreakdown.
+func commonModes(players []ComparedPlayer) []string {
+	counts := make(map[string]int)
+	loaded := 0
+	for _, p := range players {
+		if p.Stats == nil {
+			continue
+		}
+		loaded++
+		for mode := range p.Stats.ModeBreakdown {
+			counts[mode]++
+		}
+	}
+
+	modes := []string{}
+	if loaded == 0 {
+		return modes
+	}
+	for mode, n := range counts {
+		if n == loaded {
+			modes = append(modes, mode)
+		}
+	}
+	sort.Strings(modes)
+	return modes
+}
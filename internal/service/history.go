@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+// ErrInvalidQuery is returned when request parameters fail validation.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// maxHistoryPoints bounds the number of buckets a single history request may span.
+const maxHistoryPoints = 1000
+
+var historyBuckets = map[string]time.Duration{
+	"hour":  time.Hour,
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// historyMetrics maps metric names to extractors over a lifetime stats snapshot.
+var historyMetrics = map[string]func(*codclient.PlayerStats) float64{
+	"kdRatio":       func(s *codclient.PlayerStats) float64 { return s.KDRatio },
+	"kills":         func(s *codclient.PlayerStats) float64 { return float64(s.Kills) },
+	"deaths":        func(s *codclient.PlayerStats) float64 { return float64(s.Deaths) },
+	"wins":          func(s *codclient.PlayerStats) float64 { return float64(s.Wins) },
+	"losses":        func(s *codclient.PlayerStats) float64 { return float64(s.Losses) },
+	"winPct":        func(s *codclient.PlayerStats) float64 { return s.WinPct },
+	"scorePerMin":   func(s *codclient.PlayerStats) float64 { return s.ScorePerMin },
+	"matchesPlayed": func(s *codclient.PlayerStats) float64 { return float64(s.MatchesPlayed) },
+	"damageDone":    func(s *codclient.PlayerStats) float64 { return float64(s.DamageDone) },
+	"headshots":     func(s *codclient.PlayerStats) float64 { return float64(s.Headshots) },
+}
+
+// DefaultHistoryMetrics are returned when the caller doesn't choose any.
+var DefaultHistoryMetrics = []string{"kdRatio", "kills", "wins", "scorePerMin"}
+
+// StatsHistoryQuery selects a player's stats history window.
+type StatsHistoryQuery struct {
+	Title   string
+	Mode    string
+	From    time.Time
+	To      time.Time
+	Bucket  string
+	Metrics []string
+}
+
+// StatsHistoryPoint is the player's standing at the end of one bucket. Deltas are
+// the change since the previous point and are omitted for the first point.
+type StatsHistoryPoint struct {
+	Bucket    time.Time          `json:"bucket"`
+	FetchedAt time.Time          `json:"fetchedAt"`
+	Values    map[string]float64 `json:"values"`
+	Deltas    map[string]float64 `json:"deltas,omitempty"`
+}
+
+// StatsHistoryResult contains a time series of lifetime stats snapshots.
+type StatsHistoryResult struct {
+	Platform string              `json:"platform"`
+	Gamertag string              `json:"gamertag"`
+	Title    string              `json:"title"`
+	Mode     string              `json:"mode"`
+	Bucket   string              `json:"bucket"`
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Metrics  []string            `json:"metrics"`
+	Points   []StatsHistoryPoint `json:"points"`
+}
+
+// GetStatsHistory builds a bucketed time series from stored stats snapshots.
+func (s *PlayerService) GetStatsHistory(ctx context.Context, platform, gamertag string, q StatsHistoryQuery) (*StatsHistoryResult, error) {
+	t, mode, err := codclient.ResolveTitle(q.Title, q.Mode)
+	if err != nil {
+		return nil, err
+	}
+	q.Title, q.Mode = t.ID, mode
+	if q.Bucket == "" {
+		q.Bucket = "day"
+	}
+	if q.To.IsZero() {
+		q.To = time.Now()
+	}
+	if q.From.IsZero() {
+		q.From = q.To.AddDate(0, 0, -30)
+	}
+	if len(q.Metrics) == 0 {
+		q.Metrics = DefaultHistoryMetrics
+	}
+
+	width, ok := historyBuckets[q.Bucket]
+	if !ok {
+		return nil, fmt.Errorf("%w: bucket must be one of hour, day, week or month", ErrInvalidQuery)
+	}
+	if !q.From.Before(q.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	if q.To.Sub(q.From)/width > maxHistoryPoints {
+		return nil, fmt.Errorf("%w: range spans more than %d %s buckets", ErrInvalidQuery, maxHistoryPoints, q.Bucket)
+	}
+	for _, m := range q.Metrics {
+		if _, ok := historyMetrics[m]; !ok {
+			return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidQuery, m)
+		}
+	}
+
+	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, codclient.ErrPlayerNotFound
+	}
+
+	snapshots, err := s.playerRepo.GetStatsHistory(ctx, player.ID, q.Title, q.Mode, q.Bucket, q.From, q.To, maxHistoryPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StatsHistoryResult{
+		Platform: platform,
+		Gamertag: gamertag,
+		Title:    q.Title,
+		Mode:     q.Mode,
+		Bucket:   q.Bucket,
+		From:     q.From,
+		To:       q.To,
+		Metrics:  q.Metrics,
+		Points:   make([]StatsHistoryPoint, 0, len(snapshots)),
+	}
+
+	var prev map[string]float64
+	for _, snap := range snapshots {
+		data, _ := snap.StatsData.([]byte)
+		var stats codclient.PlayerStats
+		if err := json.Unmarshal(data, &stats); err != nil {
+			slog.Warn("skipping undecodable stats snapshot", "snapshot_id", snap.ID, "error", err)
+			continue
+		}
+
+		point := StatsHistoryPoint{
+			Bucket:    truncateToBucket(snap.FetchedAt, q.Bucket),
+			FetchedAt: snap.FetchedAt,
+			Values:    make(map[string]float64, len(q.Metrics)),
+		}
+		for _, m := range q.Metrics {
+			point.Values[m] = historyMetrics[m](&stats)
+		}
+		if prev != nil {
+			point.Deltas = make(map[string]float64, len(q.Metrics))
+			for _, m := range q.Metrics {
+				point.Deltas[m] = round2(point.Values[m] - prev[m])
+			}
+		}
+		prev = point.Values
+		result.Points = append(result.Points, point)
+	}
+
+	return result, nil
+}
+
+// truncateToBucket mirrors Postgres date_trunc in UTC so bucket labels match the query.
+func truncateToBucket(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	switch bucket {
+	case "hour":
+		return t.Truncate(time.Hour)
+	case "week":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		offset := (int(day.Weekday()) + 6) % 7 // ISO weeks start on Monday
+		return day.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
 	"github.com/grovecj/warzone-stats-tracker/internal/model"
 	"github.com/grovecj/warzone-stats-tracker/internal/repository"
 )
@@ -40,53 +43,46 @@ func (s *MatchService) GetRecentMatches(ctx context.Context, platform, gamertag,
 	if offset < 0 {
 		offset = 0
 	}
-
-	// Ensure player exists in DB
-	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	// Validate up front: a refresh failure falls back to stored matches and would hide it
+	t, mode, err := codclient.ResolveTitle(title, mode)
 	if err != nil {
 		return nil, err
 	}
-	if player == nil {
-		player, err = s.playerRepo.Upsert(ctx, platform, gamertag)
-		if err != nil {
-			return nil, err
-		}
-	}
+	title = t.ID
 
-	// Fetch from CoD API
-	apiMatches, err := s.codClient.GetRecentMatches(ctx, platform, gamertag, title, mode)
+	player, err := s.ensurePlayer(ctx, platform, gamertag)
 	if err != nil {
-		slog.Warn("failed to fetch matches from API, falling back to DB", "error", err)
-	} else {
-		// Convert and persist
-		modelMatches := make([]model.Match, 0, len(apiMatches))
-		for _, m := range apiMatches {
-			modelMatches = append(modelMatches, model.Match{
-				MatchID:     m.MatchID,
-				PlayerID:    player.ID,
-				Mode:        m.Mode,
-				MapName:     m.Map,
-				Placement:   m.Placement,
-				Kills:       m.Kills,
-				Deaths:      m.Deaths,
-				DamageDealt: m.DamageDealt,
-				DamageTaken: m.DamageTaken,
-				GulagResult: m.GulagResult,
-				MatchTime:   m.MatchTime,
-			})
-		}
-		if upsertErr := s.matchRepo.UpsertBatch(ctx, player.ID, modelMatches); upsertErr != nil {
-			slog.Warn("failed to persist matches", "error", upsertErr)
-		}
+		return nil, err
+	}
+
+	_, refreshErr := s.refreshMatches(ctx, player.ID, platform, gamertag, title, mode)
+	if refreshErr != nil {
+		slog.Warn("failed to refresh matches from API, falling back to DB", "error", refreshErr)
 	}
 
 	// Read from DB with pagination
-	matches, err := s.matchRepo.GetByPlayerID(ctx, player.ID, limit, offset)
+	matches, err := s.matchRepo.GetByPlayerID(ctx, player.ID, title, limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	if refreshErr != nil {
+		// The newest stored row is the best available bound on how current the list is; an
+		// empty page falls back to the player's last fetch, and with neither nothing is recorded
+		var storedAt time.Time
+		for _, m := range matches {
+			if m.CreatedAt.After(storedAt) {
+				storedAt = m.CreatedAt
+			}
+		}
+		if storedAt.IsZero() && player.LastFetchedAt != nil {
+			storedAt = *player.LastFetchedAt
+		}
+		if !storedAt.IsZero() {
+			freshness.Record(ctx, freshness.Database, storedAt)
+		}
+	}
 
-	total, err := s.matchRepo.CountByPlayerID(ctx, player.ID)
+	total, err := s.matchRepo.CountByPlayerID(ctx, player.ID, title)
 	if err != nil {
 		slog.Warn("failed to count matches", "error", err)
 		total = len(matches)
@@ -99,3 +95,151 @@ func (s *MatchService) GetRecentMatches(ctx context.Context, platform, gamertag,
 		Offset:  offset,
 	}, nil
 }
+
+// RefreshMatches fetches the latest matches from the CoD API and persists them,
+// returning how many matches the API returned.
+func (s *MatchService) RefreshMatches(ctx context.Context, platform, gamertag, title, mode string) (int, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return 0, err
+	}
+	title = t.ID
+
+	player, err := s.ensurePlayer(ctx, platform, gamertag)
+	if err != nil {
+		return 0, err
+	}
+	return s.refreshMatches(ctx, player.ID, platform, gamertag, title, mode)
+}
+
+// ensurePlayer returns the stored player, creating it if needed.
+func (s *MatchService) ensurePlayer(ctx context.Context, platform, gamertag string) (*model.Player, error) {
+	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return s.playerRepo.Upsert(ctx, platform, gamertag)
+	}
+	return player, nil
+}
+
+func (s *MatchService) refreshMatches(ctx context.Context, playerID, platform, gamertag, title, mode string) (int, error) {
+	apiMatches, err := s.codClient.GetRecentMatches(ctx, platform, gamertag, title, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.matchRepo.UpsertBatch(ctx, playerID, toModelMatches(playerID, title, apiMatches)); err != nil {
+		return len(apiMatches), fmt.Errorf("persisting matches: %w", err)
+	}
+
+	return len(apiMatches), nil
+}
+
+// toModelMatches converts CoD API matches for a title into rows for a player.
+func toModelMatches(playerID, title string, apiMatches []codclient.Match) []model.Match {
+	modelMatches := make([]model.Match, 0, len(apiMatches))
+	for _, m := range apiMatches {
+		modelMatches = append(modelMatches, model.Match{
+			MatchID:     m.MatchID,
+			PlayerID:    playerID,
+			Title:       title,
+			Mode:        m.Mode,
+			MapName:     m.Map,
+			Placement:   m.Placement,
+			Kills:       m.Kills,
+			Deaths:      m.Deaths,
+			DamageDealt: m.DamageDealt,
+			DamageTaken: m.DamageTaken,
+			GulagResult: m.GulagResult,
+			MatchTime:   m.MatchTime,
+			RawData:     m.RawData,
+
+			Headshots:         &m.Headshots,
+			Assists:           &m.Assists,
+			Score:             &m.Score,
+			TimePlayed:        &m.TimePlayed,
+			DistanceTraveled:  &m.DistanceTraveled,
+			PercentTimeMoving: &m.PercentTimeMoving,
+			Revives:           &m.Revives,
+			TeamWipes:         &m.TeamWipes,
+			CachesOpened:      &m.CachesOpened,
+			Contracts:         &m.Contracts,
+			Cash:              &m.Cash,
+		})
+	}
+	return modelMatches
+}
+
+// GetMatchDetails returns every participant in a match, reading from the database
+// first and only fetching from the CoD API on the first view. The stored players who
+// played the match are attached on every view, since more of them may have synced it.
+func (s *MatchService) GetMatchDetails(ctx context.Context, matchID, title, platform string) (*model.MatchDetails, error) {
+	t, _, err := codclient.ResolveTitle(title, "")
+	if err != nil {
+		return nil, err
+	}
+	title = t.ID
+	if platform == "" {
+		platform = "uno"
+	}
+
+	stored, err := s.matchRepo.GetDetails(ctx, matchID, title)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		freshness.Record(ctx, freshness.Database, stored.FetchedAt)
+		s.attachPlayers(ctx, stored)
+		return stored, nil
+	}
+
+	apiDetails, err := s.codClient.GetMatchDetails(ctx, title, platform, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &model.MatchDetails{
+		MatchID:      apiDetails.MatchID,
+		Title:        title,
+		Mode:         apiDetails.Mode,
+		MapName:      apiDetails.Map,
+		Duration:     apiDetails.Duration,
+		MatchTime:    apiDetails.MatchTime,
+		Participants: make([]model.MatchParticipant, 0, len(apiDetails.Participants)),
+	}
+	for _, p := range apiDetails.Participants {
+		details.Participants = append(details.Participants, model.MatchParticipant{
+			Username:    p.Username,
+			Clantag:     p.Clantag,
+			UnoID:       p.UnoID,
+			Team:        p.Team,
+			Placement:   p.Placement,
+			Kills:       p.Kills,
+			Deaths:      p.Deaths,
+			KDRatio:     p.KDRatio,
+			DamageDealt: p.DamageDealt,
+			DamageTaken: p.DamageTaken,
+		})
+	}
+
+	freshness.Record(ctx, freshness.Live, time.Now())
+	if err := s.matchRepo.SaveDetails(ctx, details); err != nil {
+		slog.Warn("failed to persist match details", "match_id", matchID, "error", err)
+	}
+	s.attachPlayers(ctx, details)
+
+	return details, nil
+}
+
+// attachPlayers fills in the stored players who played a match. A lookup failure only
+// costs the list, not the lobby.
+func (s *MatchService) attachPlayers(ctx context.Context, details *model.MatchDetails) {
+	players, err := s.matchRepo.GetPlayersInMatch(ctx, details.MatchID, details.Title)
+	if err != nil {
+		slog.Warn("failed to load players in match", "match_id", details.MatchID, "error", err)
+		return
+	}
+	details.Players = players
+}
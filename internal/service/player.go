@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 
 	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/freshness"
 	"github.com/grovecj/warzone-stats-tracker/internal/repository"
 )
 
@@ -31,17 +33,16 @@ func NewPlayerService(codClient codclient.CodClient, playerRepo *repository.Play
 // SearchPlayer verifies a player exists via the CoD API, persists them, and returns search results.
 // Falls back to database if the API is unavailable.
 func (s *PlayerService) SearchPlayer(ctx context.Context, platform, gamertag, title, mode string) (*PlayerSearchResult, error) {
-	if title == "" {
-		title = "mw"
-	}
-	if mode == "" {
-		mode = "wz"
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
 	}
+	title = t.ID
 
-	stats, err := s.codClient.GetPlayerStats(ctx, platform, gamertag, title, mode)
+	stats, live, err := s.fetchStats(ctx, platform, gamertag, title, mode)
 	if err != nil {
 		slog.Warn("cod api unavailable, falling back to database", "error", err)
-		return s.searchFromDB(ctx, platform, gamertag, mode)
+		return s.searchFromDB(ctx, platform, gamertag, title, mode)
 	}
 
 	player, err := s.playerRepo.Upsert(ctx, platform, gamertag)
@@ -49,12 +50,8 @@ func (s *PlayerService) SearchPlayer(ctx context.Context, platform, gamertag, ti
 		slog.Error("failed to upsert player", "platform", platform, "gamertag", gamertag, "error", err)
 		return nil, err
 	}
-
-	statsJSON, err := json.Marshal(stats)
-	if err == nil {
-		if saveErr := s.playerRepo.SaveStatsSnapshot(ctx, player.ID, mode, statsJSON); saveErr != nil {
-			slog.Warn("failed to save stats snapshot", "player_id", player.ID, "error", saveErr)
-		}
+	if live {
+		s.saveSnapshot(ctx, player.ID, title, mode, stats)
 	}
 
 	return &PlayerSearchResult{
@@ -68,17 +65,38 @@ func (s *PlayerService) SearchPlayer(ctx context.Context, platform, gamertag, ti
 // GetPlayerStats fetches player stats from the CoD API, upserts the player, and saves a snapshot.
 // Falls back to database if the API is unavailable.
 func (s *PlayerService) GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
-	if title == "" {
-		title = "mw"
-	}
-	if mode == "" {
-		mode = "wz"
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
 	}
+	title = t.ID
 
-	stats, err := s.codClient.GetPlayerStats(ctx, platform, gamertag, title, mode)
+	stats, err := s.RefreshStats(ctx, platform, gamertag, title, mode)
 	if err != nil {
 		slog.Warn("cod api unavailable for stats, falling back to database", "error", err)
-		return s.getStatsFromDB(ctx, platform, gamertag, mode)
+		dbStats, dbErr := s.getStatsFromDB(ctx, platform, gamertag, title, mode)
+		if errors.Is(dbErr, codclient.ErrPlayerNotFound) {
+			// Nothing stored locally — the upstream error (e.g. private profile) is more accurate
+			return nil, err
+		}
+		return dbStats, dbErr
+	}
+
+	return stats, nil
+}
+
+// RefreshStats fetches player stats from the CoD API, upserts the player, and saves a snapshot.
+// Unlike GetPlayerStats it never falls back to the database, so callers see upstream errors.
+func (s *PlayerService) RefreshStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	title = t.ID
+
+	stats, live, err := s.fetchStats(ctx, platform, gamertag, title, mode)
+	if err != nil {
+		return nil, err
 	}
 
 	player, err := s.playerRepo.Upsert(ctx, platform, gamertag)
@@ -86,19 +104,39 @@ func (s *PlayerService) GetPlayerStats(ctx context.Context, platform, gamertag,
 		slog.Error("failed to upsert player", "platform", platform, "gamertag", gamertag, "error", err)
 		return stats, nil // return stats even if DB write fails
 	}
-
-	statsJSON, err := json.Marshal(stats)
-	if err == nil {
-		if saveErr := s.playerRepo.SaveStatsSnapshot(ctx, player.ID, mode, statsJSON); saveErr != nil {
-			slog.Warn("failed to save stats snapshot", "player_id", player.ID, "error", saveErr)
-		}
+	if live {
+		s.saveSnapshot(ctx, player.ID, title, mode, stats)
 	}
 
 	return stats, nil
 }
 
+// fetchStats loads stats through the CoD client and reports whether they came live from
+// the upstream API rather than from the cache.
+func (s *PlayerService) fetchStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, bool, error) {
+	fetchCtx, rec := freshness.WithRecorder(ctx)
+	stats, err := s.codClient.GetPlayerStats(fetchCtx, platform, gamertag, title, mode)
+	if err != nil {
+		return nil, false, err
+	}
+	meta := rec.Meta()
+	return stats, meta == nil || meta.Source == freshness.Live, nil
+}
+
+// saveSnapshot stores live stats along with their raw payload. Cached stats are skipped:
+// they were snapshotted when first fetched, and the cache doesn't keep the raw payload.
+func (s *PlayerService) saveSnapshot(ctx context.Context, playerID, title, mode string, stats *codclient.PlayerStats) {
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+	if err := s.playerRepo.SaveStatsSnapshot(ctx, playerID, title, mode, statsJSON, stats.RawData); err != nil {
+		slog.Warn("failed to save stats snapshot", "player_id", playerID, "error", err)
+	}
+}
+
 // searchFromDB looks up a player and their latest stats from the database.
-func (s *PlayerService) searchFromDB(ctx context.Context, platform, gamertag, mode string) (*PlayerSearchResult, error) {
+func (s *PlayerService) searchFromDB(ctx context.Context, platform, gamertag, title, mode string) (*PlayerSearchResult, error) {
 	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
 	if err != nil {
 		return nil, err
@@ -107,7 +145,7 @@ func (s *PlayerService) searchFromDB(ctx context.Context, platform, gamertag, mo
 		return nil, codclient.ErrPlayerNotFound
 	}
 
-	stats, err := s.getStatsFromDB(ctx, platform, gamertag, mode)
+	stats, err := s.getStatsFromDB(ctx, platform, gamertag, title, mode)
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +158,8 @@ func (s *PlayerService) searchFromDB(ctx context.Context, platform, gamertag, mo
 	}, nil
 }
 
-// getStatsFromDB loads the latest stats snapshot for a player from the database.
-func (s *PlayerService) getStatsFromDB(ctx context.Context, platform, gamertag, mode string) (*codclient.PlayerStats, error) {
+// getStatsFromDB loads the player's latest stats snapshot for a title and mode from the database.
+func (s *PlayerService) getStatsFromDB(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
 	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
 	if err != nil {
 		return nil, err
@@ -130,13 +168,14 @@ func (s *PlayerService) getStatsFromDB(ctx context.Context, platform, gamertag,
 		return nil, codclient.ErrPlayerNotFound
 	}
 
-	statsData, _, err := s.playerRepo.GetLatestStats(ctx, player.ID, mode)
+	statsData, fetchedAt, err := s.playerRepo.GetLatestStats(ctx, player.ID, title, mode)
 	if err != nil {
 		return nil, err
 	}
 	if statsData == nil {
 		return nil, codclient.ErrPlayerNotFound
 	}
+	freshness.Record(ctx, freshness.Database, *fetchedAt)
 
 	// statsData is any (from pgx JSONB scan) — round-trip through JSON to decode
 	jsonBytes, err := json.Marshal(statsData)
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/repository"
+)
+
+// reprocessBatchSize is how many stored payloads are read per query.
+const reprocessBatchSize = 500
+
+// Reprocess targets.
+const (
+	ReprocessAll     = "all"
+	ReprocessMatches = "matches"
+	ReprocessStats   = "stats"
+)
+
+var ErrReprocessRunning = errors.New("reprocess already running")
+
+// ReprocessStatus reports the progress of the current or most recent reprocess job.
+type ReprocessStatus struct {
+	Target         string     `json:"target"`
+	Running        bool       `json:"running"`
+	MatchesScanned int        `json:"matchesScanned"`
+	MatchesUpdated int        `json:"matchesUpdated"`
+	StatsScanned   int        `json:"statsScanned"`
+	StatsUpdated   int        `json:"statsUpdated"`
+	Failures       int        `json:"failures"`
+	LastError      *string    `json:"lastError,omitempty"`
+	StartedAt      time.Time  `json:"startedAt"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+}
+
+// ReprocessService re-derives stored matches and stats snapshots from their raw upstream
+// payloads, so mapping fixes and newly extracted fields apply to history without refetching.
+type ReprocessService struct {
+	matchRepo  *repository.MatchRepo
+	playerRepo *repository.PlayerRepo
+
+	mu     sync.Mutex
+	status *ReprocessStatus
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReprocessService creates a new ReprocessService.
+func NewReprocessService(matchRepo *repository.MatchRepo, playerRepo *repository.PlayerRepo) *ReprocessService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReprocessService{
+		matchRepo:  matchRepo,
+		playerRepo: playerRepo,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start launches a background reprocess of the given target: all, matches or stats.
+// Only one job runs at a time.
+func (s *ReprocessService) Start(target string) (*ReprocessStatus, error) {
+	if target == "" {
+		target = ReprocessAll
+	}
+	switch target {
+	case ReprocessAll, ReprocessMatches, ReprocessStats:
+	default:
+		return nil, fmt.Errorf("%w: target must be one of all, matches or stats", ErrInvalidQuery)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status != nil && s.status.Running {
+		return nil, ErrReprocessRunning
+	}
+
+	s.status = &ReprocessStatus{Target: target, Running: true, StartedAt: time.Now()}
+	snapshot := *s.status
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(target)
+	}()
+
+	return &snapshot, nil
+}
+
+// Status returns the progress of the current or most recent job, or nil if none has run.
+func (s *ReprocessService) Status() *ReprocessStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == nil {
+		return nil
+	}
+	snapshot := *s.status
+	return &snapshot
+}
+
+// Shutdown stops a running job and waits for its current batch to finish.
+func (s *ReprocessService) Shutdown(ctx context.Context) {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("reprocess job did not stop before shutdown deadline")
+	}
+}
+
+func (s *ReprocessService) run(target string) {
+	slog.Info("reprocess started", "target", target)
+
+	var err error
+	if target == ReprocessAll || target == ReprocessMatches {
+		err = s.reprocessMatches()
+	}
+	if err == nil && (target == ReprocessAll || target == ReprocessStats) {
+		err = s.reprocessStats()
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.status.Running = false
+	s.status.CompletedAt = &now
+	if err != nil {
+		msg := err.Error()
+		s.status.LastError = &msg
+	}
+	final := *s.status
+	s.mu.Unlock()
+
+	if err != nil {
+		slog.Warn("reprocess stopped", "target", target, "error", err)
+		return
+	}
+	slog.Info("reprocess complete", "target", target,
+		"matches_updated", final.MatchesUpdated, "stats_updated", final.StatsUpdated, "failures", final.Failures)
+}
+
+// reprocessMatches re-derives every match with an upstream payload. Payloads that no longer
+// parse are counted as failures and skipped; only database errors stop the job.
+func (s *ReprocessService) reprocessMatches() error {
+	afterID := ""
+	for {
+		batch, err := s.matchRepo.ListRawMatches(s.ctx, afterID, reprocessBatchSize)
+		if err != nil {
+			return fmt.Errorf("listing matches: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, p := range batch {
+			updated, err := s.reprocessMatch(p)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return s.ctx.Err()
+				}
+				s.recordFailure("match", p.ID, err)
+				continue
+			}
+			s.mu.Lock()
+			s.status.MatchesScanned++
+			if updated {
+				s.status.MatchesUpdated++
+			}
+			s.mu.Unlock()
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
+
+func (s *ReprocessService) reprocessMatch(p model.RawPayload) (bool, error) {
+	m, err := codclient.ParseMatch(p.Data)
+	if err != nil {
+		return false, err
+	}
+	derived := toModelMatches(p.PlayerID, p.Title, []codclient.Match{m})[0]
+	return s.matchRepo.UpdateDerived(s.ctx, p.ID, derived)
+}
+
+// reprocessStats re-derives every stats snapshot with an upstream payload.
+func (s *ReprocessService) reprocessStats() error {
+	afterID := ""
+	for {
+		batch, err := s.playerRepo.ListRawStats(s.ctx, afterID, reprocessBatchSize)
+		if err != nil {
+			return fmt.Errorf("listing stats snapshots: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, p := range batch {
+			updated, err := s.reprocessSnapshot(p)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return s.ctx.Err()
+				}
+				s.recordFailure("stats snapshot", p.ID, err)
+				continue
+			}
+			s.mu.Lock()
+			s.status.StatsScanned++
+			if updated {
+				s.status.StatsUpdated++
+			}
+			s.mu.Unlock()
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
+
+func (s *ReprocessService) reprocessSnapshot(p model.RawPayload) (bool, error) {
+	stats, err := codclient.ParseProfile(p.Data, p.Title, p.Platform, p.Gamertag)
+	if err != nil {
+		return false, err
+	}
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return false, err
+	}
+	return s.playerRepo.UpdateStatsData(s.ctx, p.ID, statsJSON)
+}
+
+func (s *ReprocessService) recordFailure(kind, id string, err error) {
+	slog.Warn("failed to reprocess "+kind, "id", id, "error", err)
+
+	msg := fmt.Sprintf("%s %s: %v", kind, id, err)
+	s.mu.Lock()
+	s.status.Failures++
+	s.status.LastError = &msg
+	s.mu.Unlock()
+}
@@ -1,3 +1,312 @@
 package service
 
-// Squad business logic will be implemented in issue #16.
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/repository"
+)
+
+const (
+	// MaxSquadMembers caps squad size to match the largest Warzone team (quads).
+	MaxSquadMembers = 4
+
+	// squadStatsConcurrency bounds the number of parallel stats fetches per squad.
+	squadStatsConcurrency = 4
+)
+
+var (
+	ErrSquadNotFound = errors.New("squad not found")
+	ErrSquadFull     = errors.New("squad is full")
+)
+
+// SquadMemberStats holds one member's stats within a squad stats result.
+type SquadMemberStats struct {
+	PlayerID string                 `json:"playerId"`
+	Platform string                 `json:"platform"`
+	Gamertag string                 `json:"gamertag"`
+	Stats    *codclient.PlayerStats `json:"stats,omitempty"`
+}
+
+// SquadTotals holds summed lifetime stats across all members with available stats.
+type SquadTotals struct {
+	Kills         int `json:"kills"`
+	Deaths        int `json:"deaths"`
+	Wins          int `json:"wins"`
+	Losses        int `json:"losses"`
+	MatchesPlayed int `json:"matchesPlayed"`
+	Headshots     int `json:"headshots"`
+	Assists       int `json:"assists"`
+	DamageDone    int `json:"damageDone"`
+	TimePlayed    int `json:"timePlayed"`
+	TopFive       int `json:"topFive"`
+	TopTen        int `json:"topTen"`
+	TopTwentyFive int `json:"topTwentyFive"`
+}
+
+// SquadAverages holds derived squad-wide ratios.
+type SquadAverages struct {
+	KDRatio        float64 `json:"kdRatio"`
+	WinPct         float64 `json:"winPct"`
+	DamagePerMatch float64 `json:"damagePerMatch"`
+	KillsPerMatch  float64 `json:"killsPerMatch"`
+	ScorePerMin    float64 `json:"scorePerMin"`
+}
+
+// SquadStatsResult contains aggregated stats for every member of a squad.
+type SquadStatsResult struct {
+	SquadID          string                         `json:"squadId"`
+	Name             string                         `json:"name"`
+	MembersReporting int                            `json:"membersReporting"`
+	Members          []SquadMemberStats             `json:"members"`
+	Totals           SquadTotals                    `json:"totals"`
+	Averages         SquadAverages                  `json:"averages"`
+	ModeBreakdown    map[string]codclient.ModeStats `json:"modeBreakdown,omitempty"`
+}
+
+// SquadService handles squad-related business logic.
+type SquadService struct {
+	codClient     codclient.CodClient
+	squadRepo     *repository.SquadRepo
+	playerRepo    *repository.PlayerRepo
+	playerService *PlayerService
+}
+
+// NewSquadService creates a new SquadService.
+func NewSquadService(codClient codclient.CodClient, squadRepo *repository.SquadRepo, playerRepo *repository.PlayerRepo, playerService *PlayerService) *SquadService {
+	return &SquadService{codClient: codClient, squadRepo: squadRepo, playerRepo: playerRepo, playerService: playerService}
+}
+
+// CreateSquad creates a new, empty squad.
+func (s *SquadService) CreateSquad(ctx context.Context, name string) (*model.Squad, error) {
+	return s.squadRepo.Create(ctx, name)
+}
+
+// GetSquad returns a squad with its members.
+func (s *SquadService) GetSquad(ctx context.Context, squadID string) (*model.Squad, error) {
+	squad, err := s.squadRepo.GetByID(ctx, squadID)
+	if err != nil {
+		return nil, err
+	}
+	if squad == nil {
+		return nil, ErrSquadNotFound
+	}
+	return squad, nil
+}
+
+// RenameSquad changes a squad's name and returns the updated squad.
+func (s *SquadService) RenameSquad(ctx context.Context, squadID, name string) (*model.Squad, error) {
+	if _, err := s.GetSquad(ctx, squadID); err != nil {
+		return nil, err
+	}
+	if err := s.squadRepo.Update(ctx, squadID, name); err != nil {
+		return nil, err
+	}
+	return s.GetSquad(ctx, squadID)
+}
+
+// DeleteSquad removes a squad and its memberships.
+func (s *SquadService) DeleteSquad(ctx context.Context, squadID string) error {
+	if _, err := s.GetSquad(ctx, squadID); err != nil {
+		return err
+	}
+	return s.squadRepo.Delete(ctx, squadID)
+}
+
+// AddMember verifies a player via the CoD API, persists them, and adds them to the squad.
+func (s *SquadService) AddMember(ctx context.Context, squadID, platform, gamertag string) (*model.Squad, error) {
+	squad, err := s.GetSquad(ctx, squadID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range squad.Members {
+		if m.Platform == platform && strings.EqualFold(m.Gamertag, gamertag) {
+			return squad, nil
+		}
+	}
+
+	// Cheap early rejection; the cap is enforced atomically by squadRepo.AddMember
+	if len(squad.Members) >= MaxSquadMembers {
+		return nil, ErrSquadFull
+	}
+
+	// Resolve the player upstream so typos and private profiles are rejected up front. The
+	// lookup uses the default title and mode so it shares the stats endpoints' cache entry.
+	t, mode, err := codclient.ResolveTitle("", "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.codClient.GetPlayerStats(ctx, platform, gamertag, t.ID, mode); err != nil {
+		slog.Warn("failed to resolve squad member", "platform", platform, "gamertag", gamertag, "error", err)
+		return nil, err
+	}
+
+	player, err := s.playerRepo.Upsert(ctx, platform, gamertag)
+	if err != nil {
+		slog.Error("failed to upsert player", "platform", platform, "gamertag", gamertag, "error", err)
+		return nil, err
+	}
+
+	added, err := s.squadRepo.AddMember(ctx, squadID, player.ID, MaxSquadMembers)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		// Either another request filled the squad or it was deleted meanwhile
+		if _, err := s.GetSquad(ctx, squadID); err != nil {
+			return nil, err
+		}
+		return nil, ErrSquadFull
+	}
+
+	return s.GetSquad(ctx, squadID)
+}
+
+// RemoveMember removes a player from a squad.
+func (s *SquadService) RemoveMember(ctx context.Context, squadID, playerID string) error {
+	if _, err := s.GetSquad(ctx, squadID); err != nil {
+		return err
+	}
+	return s.squadRepo.RemoveMember(ctx, squadID, playerID)
+}
+
+// GetSquadStats fetches stats for every squad member in parallel and aggregates them.
+// Members whose stats can't be loaded are left out of the aggregates and their errors are
+// returned indexed like Members, so one bad profile doesn't fail the whole squad.
+func (s *SquadService) GetSquadStats(ctx context.Context, squadID, title, mode string) (*SquadStatsResult, []error, error) {
+	// Validate once up front so a bad title fails the request instead of every member
+	resolved, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	title = resolved.ID
+
+	squad, err := s.GetSquad(ctx, squadID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := make([]SquadMemberStats, len(squad.Members))
+	errs := make([]error, len(squad.Members))
+	sem := make(chan struct{}, squadStatsConcurrency)
+	var wg sync.WaitGroup
+	for i, p := range squad.Members {
+		members[i] = SquadMemberStats{PlayerID: p.ID, Platform: p.Platform, Gamertag: p.Gamertag}
+
+		wg.Add(1)
+		go func(m *SquadMemberStats, errp *error) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			stats, err := s.playerService.GetPlayerStats(ctx, m.Platform, m.Gamertag, title, mode)
+			if err != nil {
+				slog.Warn("failed to fetch squad member stats",
+					"squad_id", squadID, "player_id", m.PlayerID, "error", err)
+				*errp = err
+				return
+			}
+			m.Stats = stats
+		}(&members[i], &errs[i])
+	}
+	wg.Wait()
+
+	result := &SquadStatsResult{
+		SquadID: squad.ID,
+		Name:    squad.Name,
+		Members: members,
+	}
+
+	var scoreTime float64
+	modes := make(map[string]codclient.ModeStats)
+	for _, m := range members {
+		if m.Stats == nil {
+			continue
+		}
+		st := m.Stats
+		result.MembersReporting++
+		result.Totals.Kills += st.Kills
+		result.Totals.Deaths += st.Deaths
+		result.Totals.Wins += st.Wins
+		result.Totals.Losses += st.Losses
+		result.Totals.MatchesPlayed += st.MatchesPlayed
+		result.Totals.Headshots += st.Headshots
+		result.Totals.Assists += st.Assists
+		result.Totals.DamageDone += st.DamageDone
+		result.Totals.TimePlayed += st.TimePlayed
+		result.Totals.TopFive += st.TopFive
+		result.Totals.TopTen += st.TopTen
+		result.Totals.TopTwentyFive += st.TopTwentyFive
+		scoreTime += st.ScorePerMin * float64(st.TimePlayed)
+
+		mergeModeBreakdown(modes, st.ModeBreakdown)
+	}
+
+	t := result.Totals
+	result.Averages = SquadAverages{
+		KDRatio:        ratio(t.Kills, t.Deaths),
+		WinPct:         ratio(t.Wins, t.MatchesPlayed),
+		DamagePerMatch: ratio(t.DamageDone, t.MatchesPlayed),
+		KillsPerMatch:  ratio(t.Kills, t.MatchesPlayed),
+	}
+	if t.TimePlayed > 0 {
+		result.Averages.ScorePerMin = round2(scoreTime / float64(t.TimePlayed))
+	}
+	if len(modes) > 0 {
+		result.ModeBreakdown = finalizeModeBreakdown(modes)
+	}
+
+	return result, errs, nil
+}
+
+// mergeModeBreakdown sums one player's per-mode stats into acc. ScorePerMin is
+// accumulated as score-weighted time and normalised by finalizeModeBreakdown.
+func mergeModeBreakdown(acc map[string]codclient.ModeStats, breakdown map[string]codclient.ModeStats) {
+	for name, ms := range breakdown {
+		cur := acc[name]
+		cur.Kills += ms.Kills
+		cur.Deaths += ms.Deaths
+		cur.Wins += ms.Wins
+		cur.Losses += ms.Losses
+		cur.MatchesPlayed += ms.MatchesPlayed
+		cur.ScorePerMin += ms.ScorePerMin * float64(ms.TimePlayed)
+		cur.TimePlayed += ms.TimePlayed
+		cur.TopFive += ms.TopFive
+		cur.TopTen += ms.TopTen
+		cur.TopTwentyFive += ms.TopTwentyFive
+		acc[name] = cur
+	}
+}
+
+// finalizeModeBreakdown recomputes derived ratios for merged mode stats.
+func finalizeModeBreakdown(acc map[string]codclient.ModeStats) map[string]codclient.ModeStats {
+	for name, ms := range acc {
+		ms.KDRatio = ratio(ms.Kills, ms.Deaths)
+		if ms.TimePlayed > 0 {
+			ms.ScorePerMin = round2(ms.ScorePerMin / float64(ms.TimePlayed))
+		} else {
+			ms.ScorePerMin = 0
+		}
+		acc[name] = ms
+	}
+	return acc
+}
+
+// ratio returns a/b rounded to two decimals, treating a zero denominator as 1.
+func ratio(a, b int) float64 {
+	if b == 0 {
+		b = 1
+	}
+	return round2(float64(a) / float64(b))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
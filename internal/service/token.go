@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/repository"
+)
+
+// tokenListenRetry is the pause before re-establishing a dropped LISTEN connection.
+const tokenListenRetry = 5 * time.Second
+
+// TokenService manages the CoD SSO token pool. When an encryption key is configured,
+// tokens are persisted encrypted in Postgres and changes are broadcast to every
+// instance with LISTEN/NOTIFY; otherwise changes only affect this process.
+type TokenService struct {
+	codClient codclient.CodClient
+	tokenRepo *repository.TokenRepo
+	aead      cipher.AEAD
+}
+
+// NewTokenService creates a new TokenService. A nil key disables persistence.
+func NewTokenService(codClient codclient.CodClient, tokenRepo *repository.TokenRepo, key []byte) (*TokenService, error) {
+	s := &TokenService{codClient: codClient, tokenRepo: tokenRepo}
+	if key == nil {
+		slog.Warn("token encryption key not configured, sso token changes will not persist")
+		return s, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating token cipher: %w", err)
+	}
+	s.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating token cipher: %w", err)
+	}
+	return s, nil
+}
+
+func (s *TokenService) persistent() bool {
+	return s.aead != nil
+}
+
+// Load applies every stored token to the client, overriding tokens from the environment.
+func (s *TokenService) Load(ctx context.Context) error {
+	if !s.persistent() {
+		return nil
+	}
+
+	stored, err := s.tokenRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range stored {
+		token, err := s.decrypt(t)
+		if err != nil {
+			slog.Warn("skipping undecryptable stored sso token", "token", t.Name, "error", err)
+			continue
+		}
+		s.codClient.AddToken(t.Name, token)
+	}
+	slog.Info("loaded stored sso tokens", "count", len(stored))
+	return nil
+}
+
+// Update replaces the default token.
+func (s *TokenService) Update(ctx context.Context, token string) error {
+	return s.Add(ctx, codclient.DefaultTokenName, token)
+}
+
+// Add stores a named token and applies it locally; other instances pick it up via NOTIFY.
+func (s *TokenService) Add(ctx context.Context, name, token string) error {
+	if s.persistent() {
+		nonce := make([]byte, s.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return err
+		}
+		err := s.tokenRepo.Save(ctx, repository.EncryptedToken{
+			Name:       name,
+			Nonce:      nonce,
+			Ciphertext: s.aead.Seal(nil, nonce, []byte(token), []byte(name)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if name == codclient.DefaultTokenName {
+		s.codClient.UpdateToken(token)
+	} else {
+		s.codClient.AddToken(name, token)
+	}
+	return nil
+}
+
+// Remove deletes a named token, reporting whether it was in the pool.
+func (s *TokenService) Remove(ctx context.Context, name string) (bool, error) {
+	if s.persistent() {
+		if err := s.tokenRepo.Delete(ctx, name); err != nil {
+			return false, err
+		}
+	}
+	return s.codClient.RemoveToken(name), nil
+}
+
+// List describes every pooled token.
+func (s *TokenService) List() []codclient.TokenInfo {
+	return s.codClient.ListTokens()
+}
+
+// Listen applies token changes made by other instances until ctx is cancelled,
+// reconnecting if the listening connection drops.
+func (s *TokenService) Listen(ctx context.Context) {
+	if !s.persistent() {
+		return
+	}
+
+	for {
+		err := s.tokenRepo.Listen(ctx, func(name string) { s.reload(ctx, name) })
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("sso token listener disconnected, retrying", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(tokenListenRetry):
+		}
+	}
+}
+
+// reload re-reads one token after a change notification.
+func (s *TokenService) reload(ctx context.Context, name string) {
+	stored, err := s.tokenRepo.Get(ctx, name)
+	if err != nil {
+		slog.Error("failed to reload sso token", "token", name, "error", err)
+		return
+	}
+	if stored == nil {
+		s.codClient.RemoveToken(name)
+		return
+	}
+
+	token, err := s.decrypt(*stored)
+	if err != nil {
+		slog.Warn("skipping undecryptable stored sso token", "token", name, "error", err)
+		return
+	}
+	s.codClient.AddToken(name, token)
+}
+
+func (s *TokenService) decrypt(t repository.EncryptedToken) (string, error) {
+	plain, err := s.aead.Open(nil, t.Nonce, t.Ciphertext, []byte(t.Name))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/cache"
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/model"
+	"github.com/grovecj/warzone-stats-tracker/internal/repository"
+)
+
+// ErrNotTracked is returned when unfollowing a player that isn't tracked.
+var ErrNotTracked = errors.New("player is not tracked")
+
+// TrackingService manages the set of players refreshed in the background.
+type TrackingService struct {
+	trackedRepo   *repository.TrackedRepo
+	playerRepo    *repository.PlayerRepo
+	playerService *PlayerService
+	matchService  *MatchService
+}
+
+// NewTrackingService creates a new TrackingService.
+func NewTrackingService(trackedRepo *repository.TrackedRepo, playerRepo *repository.PlayerRepo, playerService *PlayerService, matchService *MatchService) *TrackingService {
+	return &TrackingService{trackedRepo: trackedRepo, playerRepo: playerRepo, playerService: playerService, matchService: matchService}
+}
+
+// Follow verifies a player via the CoD API and starts tracking them.
+func (s *TrackingService) Follow(ctx context.Context, platform, gamertag, title, mode string) (*model.TrackedPlayer, error) {
+	t, mode, err := codclient.ResolveTitle(title, mode)
+	if err != nil {
+		return nil, err
+	}
+	title = t.ID
+
+	// RefreshStats upserts the player and records an initial snapshot
+	if _, err := s.playerService.RefreshStats(ctx, platform, gamertag, title, mode); err != nil {
+		return nil, err
+	}
+	player, err := s.playerRepo.GetByPlatformAndTag(ctx, platform, gamertag)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, errors.New("player was not persisted")
+	}
+
+	if err := s.trackedRepo.Add(ctx, player.ID, title, mode); err != nil {
+		return nil, err
+	}
+	slog.Info("player tracked", "player_id", player.ID, "platform", platform, "gamertag", gamertag)
+
+	return &model.TrackedPlayer{
+		PlayerID: player.ID,
+		Platform: platform,
+		Gamertag: gamertag,
+		Title:    title,
+		Mode:     mode,
+	}, nil
+}
+
+// Unfollow stops tracking a player.
+func (s *TrackingService) Unfollow(ctx context.Context, playerID string) error {
+	removed, err := s.trackedRepo.Remove(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotTracked
+	}
+	slog.Info("player untracked", "player_id", playerID)
+	return nil
+}
+
+// List returns all tracked players, least recently refreshed first.
+func (s *TrackingService) List(ctx context.Context) ([]model.TrackedPlayer, error) {
+	return s.trackedRepo.List(ctx)
+}
+
+// Refresh fetches fresh stats and matches for a tracked player and records the outcome.
+// Soft-expired cache entries are refetched rather than served, so each pass past the
+// cache's soft TTL records a new stats snapshot.
+func (s *TrackingService) Refresh(ctx context.Context, t model.TrackedPlayer) error {
+	ctx = cache.WithRevalidate(ctx)
+	_, err := s.playerService.RefreshStats(ctx, t.Platform, t.Gamertag, t.Title, t.Mode)
+	if err == nil {
+		_, err = s.matchService.RefreshMatches(ctx, t.Platform, t.Gamertag, t.Title, t.Mode)
+	}
+
+	if markErr := s.trackedRepo.MarkRefreshed(ctx, t.PlayerID, err); markErr != nil {
+		slog.Warn("failed to record tracked player refresh", "player_id", t.PlayerID, "error", markErr)
+	}
+	return err
+}
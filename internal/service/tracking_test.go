@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/cache"
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+// countingClient serves fixed stats, counting upstream profile lookups. Methods the
+// tests don't reach are left to the nil embedded interface.
+type countingClient struct {
+	codclient.CodClient
+	calls atomic.Int32
+}
+
+func (c *countingClient) GetPlayerStats(ctx context.Context, platform, gamertag, title, mode string) (*codclient.PlayerStats, error) {
+	n := c.calls.Add(1)
+	return &codclient.PlayerStats{
+		Platform: platform,
+		Gamertag: gamertag,
+		Kills:    int(n),
+		RawData:  json.RawMessage(`{"status":"success"}`),
+	}, nil
+}
+
+func TestTrackedRefreshPastSoftTTLSnapshots(t *testing.T) {
+	upstream := &countingClient{}
+	const softTTL = 20 * time.Millisecond
+	s := &PlayerService{codClient: cache.New(upstream, nil, cache.Config{StatsSoftTTL: softTTL, StatsHardTTL: time.Hour})}
+	ctx := context.Background()
+
+	if _, live, err := s.fetchStats(ctx, "uno", "Demo#1234", "mw", "wz"); err != nil || !live {
+		t.Fatalf("first fetch live = %v, err = %v; want a live fetch", live, err)
+	}
+	time.Sleep(2 * softTTL)
+
+	// A tracked refresh runs under the context TrackingService.Refresh builds; fetchStats
+	// reporting live is what makes RefreshStats save the snapshot
+	stats, live, err := s.fetchStats(cache.WithRevalidate(ctx), "uno", "Demo#1234", "mw", "wz")
+	if err != nil {
+		t.Fatalf("tracked fetch: %v", err)
+	}
+	if !live {
+		t.Fatal("tracked fetch past the soft TTL was served from cache, so no snapshot would be saved")
+	}
+	if stats.Kills != 2 || len(stats.RawData) == 0 {
+		t.Errorf("Kills = %d, RawData = %q; want the second upstream response with its payload", stats.Kills, stats.RawData)
+	}
+	if n := upstream.calls.Load(); n != 2 {
+		t.Errorf("upstream calls = %d, want 2", n)
+	}
+
+	// Inside the soft TTL the refreshed entry is served, and nothing new is snapshotted
+	if _, live, err := s.fetchStats(cache.WithRevalidate(ctx), "uno", "Demo#1234", "mw", "wz"); err != nil || live {
+		t.Errorf("fetch within soft TTL live = %v, err = %v; want a cache hit", live, err)
+	}
+}
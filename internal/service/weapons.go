@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+)
+
+// WeaponStatsResult lists a player's weapon and weapon class stats in ranked order.
+type WeaponStatsResult struct {
+	Platform string                  `json:"platform"`
+	Gamertag string                  `json:"gamertag"`
+	Sort     string                  `json:"sort"`
+	Weapons  []codclient.WeaponStats `json:"weapons"`
+	Classes  []codclient.WeaponStats `json:"classes"`
+}
+
+// weaponSorts maps sort keys to "a ranks before b" comparisons.
+var weaponSorts = map[string]func(a, b codclient.WeaponStats) bool{
+	"kills":    func(a, b codclient.WeaponStats) bool { return a.Kills > b.Kills },
+	"accuracy": func(a, b codclient.WeaponStats) bool { return a.Accuracy > b.Accuracy },
+	"kdRatio":  func(a, b codclient.WeaponStats) bool { return a.KDRatio > b.KDRatio },
+}
+
+// GetWeaponStats returns a player's weapon stats sorted by kills, accuracy or K/D,
+// optionally limited to one weapon class.
+func (s *PlayerService) GetWeaponStats(ctx context.Context, platform, gamertag, title, mode, sortBy, class string) (*WeaponStatsResult, error) {
+	if sortBy == "" {
+		sortBy = "kills"
+	}
+	less, ok := weaponSorts[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: sort must be one of kills, accuracy or kdRatio", ErrInvalidQuery)
+	}
+
+	stats, err := s.GetPlayerStats(ctx, platform, gamertag, title, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WeaponStatsResult{
+		Platform: platform,
+		Gamertag: gamertag,
+		Sort:     sortBy,
+		Weapons:  make([]codclient.WeaponStats, 0, len(stats.Weapons)),
+		Classes:  make([]codclient.WeaponStats, 0, len(stats.WeaponClasses)),
+	}
+	for _, w := range stats.Weapons {
+		if class == "" || w.Class == class {
+			result.Weapons = append(result.Weapons, w)
+		}
+	}
+	for _, c := range stats.WeaponClasses {
+		if class == "" || c.Class == class {
+			result.Classes = append(result.Classes, c)
+		}
+	}
+
+	sortWeapons(result.Weapons, less)
+	sortWeapons(result.Classes, less)
+	return result, nil
+}
+
+// sortWeapons orders by the chosen metric, breaking ties by name for stable output.
+func sortWeapons(list []codclient.WeaponStats, less func(a, b codclient.WeaponStats) bool) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if less(list[i], list[j]) {
+			return true
+		}
+		if less(list[j], list[i]) {
+			return false
+		}
+		return list[i].Name+list[i].Class < list[j].Name+list[j].Class
+	})
+}
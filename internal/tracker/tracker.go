@@ -0,0 +1,88 @@
+// Package tracker periodically refreshes stats and matches for followed players
+// so match history keeps accumulating without anyone hitting the API.
+package tracker
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/grovecj/warzone-stats-tracker/internal/codclient"
+	"github.com/grovecj/warzone-stats-tracker/internal/service"
+)
+
+// playerSpacing is the pause between refreshing consecutive players, to spread upstream load.
+const playerSpacing = 2 * time.Second
+
+// Scheduler refreshes tracked players on a fixed interval plus random jitter.
+type Scheduler struct {
+	tracking *service.TrackingService
+	interval time.Duration
+	jitter   time.Duration
+}
+
+// New creates a Scheduler. Run does nothing if interval is not positive.
+func New(tracking *service.TrackingService, interval, jitter time.Duration) *Scheduler {
+	return &Scheduler{tracking: tracking, interval: interval, jitter: jitter}
+}
+
+// Run refreshes tracked players until ctx is cancelled. It returns once any
+// in-progress refresh has observed the cancellation.
+func (s *Scheduler) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		slog.Info("tracker disabled")
+		return
+	}
+	slog.Info("tracker started", "interval", s.interval, "jitter", s.jitter)
+	ctx = codclient.WithPriority(ctx, codclient.PriorityBackground)
+
+	for {
+		timer := time.NewTimer(s.nextDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			slog.Info("tracker stopped")
+			return
+		case <-timer.C:
+		}
+
+		s.refreshAll(ctx)
+	}
+}
+
+func (s *Scheduler) nextDelay() time.Duration {
+	if s.jitter <= 0 {
+		return s.interval
+	}
+	return s.interval + rand.N(s.jitter)
+}
+
+func (s *Scheduler) refreshAll(ctx context.Context) {
+	tracked, err := s.tracking.List(ctx)
+	if err != nil {
+		slog.Error("failed to list tracked players", "error", err)
+		return
+	}
+
+	start := time.Now()
+	failed := 0
+	for i, t := range tracked {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(playerSpacing):
+			}
+		}
+
+		if err := s.tracking.Refresh(ctx, t); err != nil {
+			failed++
+			slog.Warn("tracked player refresh failed",
+				"player_id", t.PlayerID, "platform", t.Platform, "gamertag", t.Gamertag, "error", err)
+		}
+	}
+
+	slog.Info("tracker cycle complete",
+		"players", len(tracked), "failed", failed, "duration_ms", time.Since(start).Milliseconds())
+}
@@ -0,0 +1,10 @@
+CREATE TABLE tracked_players (
+    player_id         UUID PRIMARY KEY REFERENCES players(id) ON DELETE CASCADE,
+    title             VARCHAR(20) NOT NULL DEFAULT 'mw',
+    mode              VARCHAR(50) NOT NULL DEFAULT 'wz',
+    last_refreshed_at TIMESTAMPTZ,
+    last_error        TEXT,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tracked_players_last_refreshed ON tracked_players(last_refreshed_at NULLS FIRST);
@@ -0,0 +1,14 @@
+CREATE TABLE match_backfill_progress (
+    player_id       UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    title           VARCHAR(20) NOT NULL,
+    mode            VARCHAR(50) NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    cursor_end      TIMESTAMPTZ,
+    pages_fetched   INT NOT NULL DEFAULT 0,
+    matches_found   INT NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    started_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMPTZ,
+    PRIMARY KEY (player_id, title, mode)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS match_participants;
+DROP TABLE IF EXISTS match_details;
@@ -0,0 +1,26 @@
+CREATE TABLE match_details (
+    match_id        VARCHAR(100) PRIMARY KEY,
+    title           VARCHAR(20) NOT NULL,
+    mode            VARCHAR(50),
+    map_name        VARCHAR(100),
+    duration        INT,
+    match_time      TIMESTAMPTZ,
+    fetched_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE match_participants (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id        VARCHAR(100) NOT NULL REFERENCES match_details(match_id) ON DELETE CASCADE,
+    username        VARCHAR(100) NOT NULL,
+    clantag         VARCHAR(50),
+    uno_id          VARCHAR(100),
+    team            VARCHAR(50),
+    placement       INT,
+    kills           INT,
+    deaths          INT,
+    kd_ratio        DOUBLE PRECISION,
+    damage_dealt    INT,
+    damage_taken    INT
+);
+
+CREATE INDEX idx_match_participants_match_id ON match_participants(match_id);
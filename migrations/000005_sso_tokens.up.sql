@@ -0,0 +1,6 @@
+CREATE TABLE sso_tokens (
+    name            VARCHAR(50) PRIMARY KEY,
+    nonce           BYTEA NOT NULL,
+    ciphertext      BYTEA NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,11 @@
+-- Shared response cache for CachedClient's postgres backend. UNLOGGED skips the WAL:
+-- entries are cheap to lose on a crash but survive normal restarts and deploys.
+CREATE UNLOGGED TABLE cache_entries (
+    key             TEXT PRIMARY KEY,
+    value           JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    stale_at        TIMESTAMPTZ NOT NULL,
+    expires_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_cache_entries_expires_at ON cache_entries(expires_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_matches_upstream_raw;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS raw_data;
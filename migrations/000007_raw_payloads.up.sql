@@ -0,0 +1,7 @@
+-- Keep the upstream profile payload next to each stats snapshot so stats_data can be
+-- re-derived when the profile mapper learns new fields.
+ALTER TABLE player_stats ADD COLUMN raw_data JSONB;
+
+-- Until now matches.raw_data held our own re-marshalled row rather than the upstream
+-- payload. Upstream payloads are recognisable by their playerStats object.
+CREATE INDEX idx_matches_upstream_raw ON matches(id) WHERE raw_data ? 'playerStats';
@@ -0,0 +1,12 @@
+ALTER TABLE matches
+    DROP COLUMN IF EXISTS headshots,
+    DROP COLUMN IF EXISTS assists,
+    DROP COLUMN IF EXISTS score,
+    DROP COLUMN IF EXISTS time_played,
+    DROP COLUMN IF EXISTS distance_traveled,
+    DROP COLUMN IF EXISTS percent_time_moving,
+    DROP COLUMN IF EXISTS revives,
+    DROP COLUMN IF EXISTS team_wipes,
+    DROP COLUMN IF EXISTS caches_opened,
+    DROP COLUMN IF EXISTS contracts,
+    DROP COLUMN IF EXISTS cash;
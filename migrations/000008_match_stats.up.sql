@@ -0,0 +1,14 @@
+-- Extended per-match player stats. They stay NULL for rows stored before this migration
+-- until POST /api/v1/admin/reprocess re-derives them from raw_data.
+ALTER TABLE matches
+    ADD COLUMN headshots           INT,
+    ADD COLUMN assists             INT,
+    ADD COLUMN score               INT,
+    ADD COLUMN time_played         INT,
+    ADD COLUMN distance_traveled   DOUBLE PRECISION,
+    ADD COLUMN percent_time_moving DOUBLE PRECISION,
+    ADD COLUMN revives             INT,
+    ADD COLUMN team_wipes          INT,
+    ADD COLUMN caches_opened       INT,
+    ADD COLUMN contracts           INT,
+    ADD COLUMN cash                INT;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_matches_player_title_time;
+DROP INDEX IF EXISTS idx_matches_match_player_title;
+-- Rows that only differ by title collapse to one when the title column goes away
+DELETE FROM matches a USING matches b
+WHERE a.match_id = b.match_id AND a.player_id = b.player_id AND a.id > b.id;
+CREATE UNIQUE INDEX idx_matches_match_player ON matches(match_id, player_id);
+ALTER TABLE matches DROP COLUMN IF EXISTS title;
+
+DROP INDEX IF EXISTS idx_player_stats_player_title_mode;
+CREATE INDEX idx_player_stats_player_mode ON player_stats(player_id, mode);
+ALTER TABLE player_stats DROP COLUMN IF EXISTS title;
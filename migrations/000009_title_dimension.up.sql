@@ -0,0 +1,11 @@
+-- Every row stored so far came from the default title (mw)
+ALTER TABLE player_stats ADD COLUMN title VARCHAR(20) NOT NULL DEFAULT 'mw';
+ALTER TABLE player_stats ALTER COLUMN title DROP DEFAULT;
+DROP INDEX IF EXISTS idx_player_stats_player_mode;
+CREATE INDEX idx_player_stats_player_title_mode ON player_stats(player_id, title, mode, fetched_at DESC);
+
+ALTER TABLE matches ADD COLUMN title VARCHAR(20) NOT NULL DEFAULT 'mw';
+ALTER TABLE matches ALTER COLUMN title DROP DEFAULT;
+DROP INDEX IF EXISTS idx_matches_match_player;
+CREATE UNIQUE INDEX idx_matches_match_player_title ON matches(match_id, player_id, title);
+CREATE INDEX idx_matches_player_title_time ON matches(player_id, title, match_time DESC);
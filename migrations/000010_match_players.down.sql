@@ -0,0 +1,79 @@
+CREATE TABLE match_details (
+    match_id        VARCHAR(100) PRIMARY KEY,
+    title           VARCHAR(20) NOT NULL,
+    mode            VARCHAR(50),
+    map_name        VARCHAR(100),
+    duration        INT,
+    match_time      TIMESTAMPTZ,
+    fetched_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- match_details is keyed by match ID alone, so only the latest lobby per ID survives
+INSERT INTO match_details (match_id, title, mode, map_name, duration, match_time, fetched_at)
+SELECT DISTINCT ON (match_id) match_id, title, mode, map_name, duration, match_time, details_fetched_at
+FROM matches
+WHERE details_fetched_at IS NOT NULL
+ORDER BY match_id, details_fetched_at DESC;
+
+DELETE FROM match_participants mp
+WHERE NOT EXISTS (
+    SELECT 1 FROM match_details md WHERE md.match_id = mp.match_id AND md.title = mp.title
+);
+ALTER TABLE match_participants
+    DROP CONSTRAINT match_participants_match_fkey,
+    DROP COLUMN title,
+    ADD CONSTRAINT match_participants_match_id_fkey FOREIGN KEY (match_id)
+        REFERENCES match_details(match_id) ON DELETE CASCADE;
+CREATE INDEX idx_match_participants_match_id ON match_participants(match_id);
+
+CREATE TABLE matches_by_player (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id            VARCHAR(100) NOT NULL,
+    player_id           UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    title               VARCHAR(20) NOT NULL,
+    mode                VARCHAR(50),
+    map_name            VARCHAR(100),
+    placement           INT,
+    kills               INT,
+    deaths              INT,
+    damage_dealt        INT,
+    damage_taken        INT,
+    gulag_result        VARCHAR(10),
+    match_time          TIMESTAMPTZ,
+    raw_data            JSONB,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    headshots           INT,
+    assists             INT,
+    score               INT,
+    time_played         INT,
+    distance_traveled   DOUBLE PRECISION,
+    percent_time_moving DOUBLE PRECISION,
+    revives             INT,
+    team_wipes          INT,
+    caches_opened       INT,
+    contracts           INT,
+    cash                INT
+);
+
+INSERT INTO matches_by_player (id, match_id, player_id, title, mode, map_name, placement,
+    kills, deaths, damage_dealt, damage_taken, gulag_result, match_time, raw_data, created_at,
+    headshots, assists, score, time_played, distance_traveled, percent_time_moving,
+    revives, team_wipes, caches_opened, contracts, cash)
+SELECT mp.id, mp.match_id, mp.player_id, mp.title, m.mode, m.map_name, mp.placement,
+    mp.kills, mp.deaths, mp.damage_dealt, mp.damage_taken, mp.gulag_result, m.match_time,
+    mp.raw_data, mp.created_at, mp.headshots, mp.assists, mp.score, mp.time_played,
+    mp.distance_traveled, mp.percent_time_moving, mp.revives, mp.team_wipes,
+    mp.caches_opened, mp.contracts, mp.cash
+FROM match_players mp
+JOIN matches m ON m.match_id = mp.match_id AND m.title = mp.title;
+
+DROP TABLE match_players;
+DROP TABLE matches;
+ALTER TABLE matches_by_player RENAME TO matches;
+ALTER TABLE matches RENAME CONSTRAINT matches_by_player_pkey TO matches_pkey;
+
+CREATE UNIQUE INDEX idx_matches_match_player_title ON matches(match_id, player_id, title);
+CREATE INDEX idx_matches_player_title_time ON matches(player_id, title, match_time DESC);
+CREATE INDEX idx_matches_player_id ON matches(player_id);
+CREATE INDEX idx_matches_match_time ON matches(match_time);
+CREATE INDEX idx_matches_upstream_raw ON matches(id) WHERE raw_data ? 'playerStats';
@@ -0,0 +1,91 @@
+-- Split the per-player matches table into one shared row per match plus one row per
+-- stored player in it. Player rows keep their IDs so API responses don't change. Full
+-- lobbies fold into the shared row too, replacing match_details.
+ALTER TABLE matches RENAME TO matches_by_player;
+ALTER TABLE matches_by_player RENAME CONSTRAINT matches_pkey TO matches_by_player_pkey;
+DROP INDEX IF EXISTS idx_matches_match_player_title;
+DROP INDEX IF EXISTS idx_matches_player_title_time;
+DROP INDEX IF EXISTS idx_matches_player_id;
+DROP INDEX IF EXISTS idx_matches_match_time;
+DROP INDEX IF EXISTS idx_matches_upstream_raw;
+
+CREATE TABLE matches (
+    match_id            VARCHAR(100) NOT NULL,
+    title               VARCHAR(20) NOT NULL,
+    mode                VARCHAR(50),
+    map_name            VARCHAR(100),
+    match_time          TIMESTAMPTZ,
+    duration            INT,
+    details_fetched_at  TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (match_id, title)
+);
+CREATE INDEX idx_matches_match_time ON matches(match_time);
+
+CREATE TABLE match_players (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    match_id            VARCHAR(100) NOT NULL,
+    title               VARCHAR(20) NOT NULL,
+    player_id           UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    placement           INT,
+    kills               INT,
+    deaths              INT,
+    damage_dealt        INT,
+    damage_taken        INT,
+    gulag_result        VARCHAR(10),
+    headshots           INT,
+    assists             INT,
+    score               INT,
+    time_played         INT,
+    distance_traveled   DOUBLE PRECISION,
+    percent_time_moving DOUBLE PRECISION,
+    revives             INT,
+    team_wipes          INT,
+    caches_opened       INT,
+    contracts           INT,
+    cash                INT,
+    raw_data            JSONB,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (match_id, title) REFERENCES matches(match_id, title) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_match_players_match_player ON match_players(match_id, title, player_id);
+CREATE INDEX idx_match_players_player_title ON match_players(player_id, title);
+CREATE INDEX idx_match_players_upstream_raw ON match_players(id) WHERE raw_data ? 'playerStats';
+
+-- Shared columns come from the first row stored for each match
+INSERT INTO matches (match_id, title, mode, map_name, match_time, created_at)
+SELECT DISTINCT ON (match_id, title) match_id, title, mode, map_name, match_time, created_at
+FROM matches_by_player
+ORDER BY match_id, title, created_at, id;
+
+INSERT INTO match_players (id, match_id, title, player_id, placement, kills, deaths,
+    damage_dealt, damage_taken, gulag_result, headshots, assists, score, time_played,
+    distance_traveled, percent_time_moving, revives, team_wipes, caches_opened, contracts,
+    cash, raw_data, created_at)
+SELECT id, match_id, title, player_id, placement, kills, deaths,
+    damage_dealt, damage_taken, gulag_result, headshots, assists, score, time_played,
+    distance_traveled, percent_time_moving, revives, team_wipes, caches_opened, contracts,
+    cash, raw_data, created_at
+FROM matches_by_player;
+
+DROP TABLE matches_by_player;
+
+-- Lobbies fetched for matches no stored player has played still get a shared row
+INSERT INTO matches (match_id, title, mode, map_name, match_time, duration, details_fetched_at)
+SELECT match_id, title, mode, map_name, match_time, duration, fetched_at
+FROM match_details
+ON CONFLICT (match_id, title) DO UPDATE
+    SET duration = EXCLUDED.duration, details_fetched_at = EXCLUDED.details_fetched_at;
+
+ALTER TABLE match_participants ADD COLUMN title VARCHAR(20);
+UPDATE match_participants mp SET title = md.title
+FROM match_details md WHERE md.match_id = mp.match_id;
+ALTER TABLE match_participants
+    ALTER COLUMN title SET NOT NULL,
+    DROP CONSTRAINT match_participants_match_id_fkey,
+    ADD CONSTRAINT match_participants_match_fkey FOREIGN KEY (match_id, title)
+        REFERENCES matches(match_id, title) ON DELETE CASCADE;
+DROP INDEX idx_match_participants_match_id;
+CREATE INDEX idx_match_participants_match_title ON match_participants(match_id, title);
+
+DROP TABLE match_details;
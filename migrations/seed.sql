@@ -3,7 +3,7 @@
 
 BEGIN;
 
--- Clean previous seed data (idempotent)
+-- Clean previous seed data (idempotent); match_players rows cascade
 DELETE FROM matches WHERE match_id LIKE 'demo-%';
 DELETE FROM player_stats WHERE player_id IN (
     SELECT id FROM players WHERE id IN (
@@ -33,12 +33,12 @@ INSERT INTO players (id, platform, gamertag, last_fetched_at) VALUES
 ('a0000001-0000-0000-0000-000000000005', 'battle', 'NoobMaster69',      NOW());
 
 --------------------------------------------------------------------------------
--- 2. Lifetime stats snapshots (one per player, mode = 'wz')
+-- 2. Lifetime stats snapshots (one per player, title = 'mw', mode = 'wz')
 --------------------------------------------------------------------------------
 
 -- TacticalNuke99 — Elite (K/D 2.51)
-INSERT INTO player_stats (player_id, mode, stats_data) VALUES
-('a0000001-0000-0000-0000-000000000001', 'wz', '{
+INSERT INTO player_stats (player_id, title, mode, stats_data) VALUES
+('a0000001-0000-0000-0000-000000000001', 'mw', 'wz', '{
   "platform": "xbl",
   "gamertag": "TacticalNuke99",
   "level": 155,
@@ -87,8 +87,8 @@ INSERT INTO player_stats (player_id, mode, stats_data) VALUES
 }'::jsonb);
 
 -- ShadowSniper_TTV — Good (K/D 1.68)
-INSERT INTO player_stats (player_id, mode, stats_data) VALUES
-('a0000001-0000-0000-0000-000000000002', 'wz', '{
+INSERT INTO player_stats (player_id, title, mode, stats_data) VALUES
+('a0000001-0000-0000-0000-000000000002', 'mw', 'wz', '{
   "platform": "psn",
   "gamertag": "ShadowSniper_TTV",
   "level": 142,
@@ -131,8 +131,8 @@ INSERT INTO player_stats (player_id, mode, stats_data) VALUES
 }'::jsonb);
 
 -- CasualCarl — Average (K/D 0.94)
-INSERT INTO player_stats (player_id, mode, stats_data) VALUES
-('a0000001-0000-0000-0000-000000000003', 'wz', '{
+INSERT INTO player_stats (player_id, title, mode, stats_data) VALUES
+('a0000001-0000-0000-0000-000000000003', 'mw', 'wz', '{
   "platform": "xbl",
   "gamertag": "CasualCarl",
   "level": 87,
@@ -175,8 +175,8 @@ INSERT INTO player_stats (player_id, mode, stats_data) VALUES
 }'::jsonb);
 
 -- GhostRecon42 — Above Average (K/D 1.31)
-INSERT INTO player_stats (player_id, mode, stats_data) VALUES
-('a0000001-0000-0000-0000-000000000004', 'wz', '{
+INSERT INTO player_stats (player_id, title, mode, stats_data) VALUES
+('a0000001-0000-0000-0000-000000000004', 'mw', 'wz', '{
   "platform": "uno",
   "gamertag": "GhostRecon42",
   "level": 121,
@@ -225,8 +225,8 @@ INSERT INTO player_stats (player_id, mode, stats_data) VALUES
 }'::jsonb);
 
 -- NoobMaster69 — Below Average (K/D 0.62)
-INSERT INTO player_stats (player_id, mode, stats_data) VALUES
-('a0000001-0000-0000-0000-000000000005', 'wz', '{
+INSERT INTO player_stats (player_id, title, mode, stats_data) VALUES
+('a0000001-0000-0000-0000-000000000005', 'mw', 'wz', '{
   "platform": "battle",
   "gamertag": "NoobMaster69",
   "level": 45,
@@ -335,25 +335,33 @@ BEGIN
             ELSE gulag := '';
             END IF;
 
-            INSERT INTO matches (
-                match_id, player_id, mode, map_name, placement,
+            INSERT INTO matches (match_id, title, mode, map_name, match_time)
+            VALUES (
+                'demo-' || left(p.gamertag, 12) || '-' || i,
+                'mw',
+                modes[1 + mod(i - 1, 6)],
+                maps[1 + mod(i + ascii(left(p.gamertag, 1)), 6)],
+                NOW() - make_interval(hours => i * 6 + mod(ascii(left(p.gamertag, 1)), 12))
+            )
+            ON CONFLICT (match_id, title) DO NOTHING;
+
+            INSERT INTO match_players (
+                match_id, title, player_id, placement,
                 kills, deaths, damage_dealt, damage_taken,
-                gulag_result, match_time, raw_data
+                gulag_result, raw_data
             ) VALUES (
                 'demo-' || left(p.gamertag, 12) || '-' || i,
+                'mw',
                 p.id,
-                modes[1 + mod(i - 1, 6)],
-                maps[1 + mod(i + ascii(left(p.gamertag, 1)), 6)],
                 pl,
                 k,
                 d,
                 dmg_dealt,
                 dmg_taken,
                 gulag,
-                NOW() - make_interval(hours => i * 6 + mod(ascii(left(p.gamertag, 1)), 12)),
                 '{}'::jsonb
             )
-            ON CONFLICT (match_id, player_id) DO NOTHING;
+            ON CONFLICT (match_id, title, player_id) DO NOTHING;
         END LOOP;
     END LOOP;
 END $$;
@@ -57,6 +57,7 @@ export interface Match {
   matchId?: string
   matchID?: string
   playerId?: string
+  title?: string
   mode: string
   map?: string
   mapName?: string
@@ -70,6 +71,17 @@ export interface Match {
   duration?: number
   matchTime: string
   createdAt?: string
+  headshots?: number
+  assists?: number
+  score?: number
+  timePlayed?: number
+  distanceTraveled?: number
+  percentTimeMoving?: number
+  revives?: number
+  teamWipes?: number
+  cachesOpened?: number
+  contracts?: number
+  cash?: number
 }
 
 export interface MatchListResult {